@@ -0,0 +1,43 @@
+package bimap
+
+import (
+	"testing"
+)
+
+func BenchmarkBiMap_Store(b *testing.B) {
+	b.Run("bimap", func(b *testing.B) {
+		b.ReportAllocs()
+
+		m := New[int, int]()
+
+		for i := 0; i < b.N; i++ {
+			m.Store(i, i)
+		}
+	})
+
+	b.Run("presized bimap", func(b *testing.B) {
+		b.ReportAllocs()
+
+		m := NewPresized[int, int](b.N)
+
+		for i := 0; i < b.N; i++ {
+			m.Store(i, i)
+		}
+	})
+}
+
+func BenchmarkBiMap_LoadKey(b *testing.B) {
+	b.ReportAllocs()
+
+	m := NewPresized[int, int](b.N)
+
+	for i := 0; i < b.N; i++ {
+		m.Store(i, i)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.LoadKey(i)
+	}
+}
@@ -0,0 +1,172 @@
+// Package bimap implements a bidirectional ordered map with a double linked list and a node pool.
+//
+// To iterate over a map (where m is a *BiMap):
+//
+//	it := m.Iter()
+//	for it.Next() {
+//		// do something with it.Key() and it.Val()
+//	}
+package bimap
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/glebziz/containers/internal/iter"
+	"github.com/glebziz/containers/internal/node"
+)
+
+// ErrConflict is returned when the value is already mapped to another key.
+var ErrConflict = errors.New("bimap: value is already mapped to another key")
+
+// BiMap represents a bidirectional ordered map.
+// Every key is mapped to exactly one value and every value to exactly one key.
+// The zero value for BiMap is an empty map ready to use.
+type BiMap[K, V comparable] struct {
+	keys map[K]*node.Node[node.Entry[K, V]]
+	vals map[V]*node.Node[node.Entry[K, V]]
+	root node.Node[node.Entry[K, V]]
+	pool *node.Pool[node.Entry[K, V]]
+
+	m sync.RWMutex
+}
+
+// New returns an initialized map.
+func New[K, V comparable]() *BiMap[K, V] {
+	p := node.NewPool[node.Entry[K, V]]()
+
+	return &BiMap[K, V]{
+		keys: make(map[K]*node.Node[node.Entry[K, V]], p.Cap()),
+		vals: make(map[V]*node.Node[node.Entry[K, V]], p.Cap()),
+		pool: p,
+	}
+}
+
+// NewPresized returns an initialized map with an allocated pool of nodes.
+func NewPresized[K, V comparable](size int) *BiMap[K, V] {
+	return &BiMap[K, V]{
+		keys: make(map[K]*node.Node[node.Entry[K, V]], size),
+		vals: make(map[V]*node.Node[node.Entry[K, V]], size),
+		pool: node.NewPoolPresized[node.Entry[K, V]](size),
+	}
+}
+
+// Len returns the number of pairs of map.
+func (m *BiMap[K, V]) Len() int {
+	return len(m.keys)
+}
+
+// Iter returns an iterator of the ordered map.
+func (m *BiMap[K, V]) Iter() *iter.MapIter[K, V] {
+	return iter.NewMap[K, V](&m.root, iter.ForwardDir)
+}
+
+// Store stores the pair at the back of the map.
+// The previous value of the key and the previous key of the value are removed.
+// The complexity is O(1).
+func (m *BiMap[K, V]) Store(key K, val V) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.store(key, val)
+}
+
+// TryStore stores the pair at the back of the map like Store,
+// but returns ErrConflict without changes if the value is already mapped to another key.
+// The complexity is O(1).
+func (m *BiMap[K, V]) TryStore(key K, val V) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	if n, ok := m.vals[val]; ok && n.Val().Key != key {
+		return ErrConflict
+	}
+
+	m.store(key, val)
+	return nil
+}
+
+// Load returns the value by key from the map.
+// The complexity is O(1).
+func (m *BiMap[K, V]) Load(key K) (val V, ok bool) {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	n, ok := m.keys[key]
+	return n.Val().Val, ok
+}
+
+// LoadKey returns the key by value from the map.
+// The complexity is O(1).
+func (m *BiMap[K, V]) LoadKey(val V) (key K, ok bool) {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	n, ok := m.vals[val]
+	return n.Val().Key, ok
+}
+
+// Delete removes the pair by key from the map and reports whether it was present.
+// The complexity is O(1).
+func (m *BiMap[K, V]) Delete(key K) bool {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	n, ok := m.keys[key]
+	if ok {
+		m.remove(n)
+	}
+
+	return ok
+}
+
+// DeleteValue removes the pair by value from the map and reports whether it was present.
+// The complexity is O(1).
+func (m *BiMap[K, V]) DeleteValue(val V) bool {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	n, ok := m.vals[val]
+	if ok {
+		m.remove(n)
+	}
+
+	return ok
+}
+
+// store removes the conflicting pairs and stores the pair at the back of the map.
+func (m *BiMap[K, V]) store(key K, val V) {
+	if n, ok := m.keys[key]; ok {
+		m.remove(n)
+	}
+
+	if n, ok := m.vals[val]; ok {
+		m.remove(n)
+	}
+
+	if m.pool == nil {
+		m.pool = node.NewPool[node.Entry[K, V]]()
+		m.keys = make(map[K]*node.Node[node.Entry[K, V]], m.pool.Cap())
+		m.vals = make(map[V]*node.Node[node.Entry[K, V]], m.pool.Cap())
+	}
+
+	if m.root.Next() == nil {
+		m.root.SetNext(&m.root)
+		m.root.SetPrev(&m.root)
+	}
+
+	n := m.pool.Pop()
+	n.SetVal(node.Entry[K, V]{Key: key, Val: val})
+	m.root.Prev().Insert(n)
+	m.keys[key] = n
+	m.vals[val] = n
+}
+
+// remove removes n from both directions of the map.
+func (m *BiMap[K, V]) remove(n *node.Node[node.Entry[K, V]]) {
+	e := n.Val()
+	delete(m.keys, e.Key)
+	delete(m.vals, e.Val)
+	n.Remove()
+	m.pool.Push(n)
+}
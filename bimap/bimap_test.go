@@ -0,0 +1,259 @@
+package bimap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type pair struct {
+	k int
+	v string
+}
+
+func TestBiMap_Store(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		k        int
+		v        string
+		m        func() *BiMap[int, string]
+		expPairs []pair
+	}{
+		{
+			name: "zero map",
+			k:    1,
+			v:    "a",
+			m: func() *BiMap[int, string] {
+				return &BiMap[int, string]{}
+			},
+			expPairs: []pair{{1, "a"}},
+		},
+		{
+			name: "new pair",
+			k:    2,
+			v:    "b",
+			m: func() *BiMap[int, string] {
+				return newBiMap(pair{1, "a"})
+			},
+			expPairs: []pair{{1, "a"}, {2, "b"}},
+		},
+		{
+			name: "existing key",
+			k:    1,
+			v:    "c",
+			m: func() *BiMap[int, string] {
+				return newBiMap(pair{1, "a"}, pair{2, "b"})
+			},
+			expPairs: []pair{{2, "b"}, {1, "c"}},
+		},
+		{
+			name: "existing value",
+			k:    3,
+			v:    "a",
+			m: func() *BiMap[int, string] {
+				return newBiMap(pair{1, "a"}, pair{2, "b"})
+			},
+			expPairs: []pair{{2, "b"}, {3, "a"}},
+		},
+		{
+			name: "existing key and value",
+			k:    1,
+			v:    "b",
+			m: func() *BiMap[int, string] {
+				return newBiMap(pair{1, "a"}, pair{2, "b"}, pair{3, "c"})
+			},
+			expPairs: []pair{{3, "c"}, {1, "b"}},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := tc.m()
+			m.Store(tc.k, tc.v)
+			require.Equal(t, tc.expPairs, pairs(m))
+			require.Equal(t, len(tc.expPairs), m.Len())
+			checkInverse(t, m)
+		})
+	}
+}
+
+func TestBiMap_TryStore(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		k        int
+		v        string
+		m        func() *BiMap[int, string]
+		expErr   error
+		expPairs []pair
+	}{
+		{
+			name: "zero map",
+			k:    1,
+			v:    "a",
+			m: func() *BiMap[int, string] {
+				return &BiMap[int, string]{}
+			},
+			expPairs: []pair{{1, "a"}},
+		},
+		{
+			name: "existing key",
+			k:    1,
+			v:    "c",
+			m: func() *BiMap[int, string] {
+				return newBiMap(pair{1, "a"}, pair{2, "b"})
+			},
+			expPairs: []pair{{2, "b"}, {1, "c"}},
+		},
+		{
+			name: "same pair",
+			k:    1,
+			v:    "a",
+			m: func() *BiMap[int, string] {
+				return newBiMap(pair{1, "a"}, pair{2, "b"})
+			},
+			expPairs: []pair{{2, "b"}, {1, "a"}},
+		},
+		{
+			name: "conflicting value",
+			k:    1,
+			v:    "b",
+			m: func() *BiMap[int, string] {
+				return newBiMap(pair{1, "a"}, pair{2, "b"})
+			},
+			expErr:   ErrConflict,
+			expPairs: []pair{{1, "a"}, {2, "b"}},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := tc.m()
+			err := m.TryStore(tc.k, tc.v)
+			require.ErrorIs(t, err, tc.expErr)
+			require.Equal(t, tc.expPairs, pairs(m))
+			checkInverse(t, m)
+		})
+	}
+}
+
+func TestBiMap_Load(t *testing.T) {
+	m := newBiMap(pair{1, "a"}, pair{2, "b"})
+
+	v, ok := m.Load(2)
+	require.True(t, ok)
+	require.Equal(t, "b", v)
+
+	k, ok := m.LoadKey("a")
+	require.True(t, ok)
+	require.Equal(t, 1, k)
+
+	v, ok = m.Load(10)
+	require.False(t, ok)
+	require.Zero(t, v)
+
+	k, ok = m.LoadKey("z")
+	require.False(t, ok)
+	require.Zero(t, k)
+
+	var zero BiMap[int, string]
+
+	_, ok = zero.Load(1)
+	require.False(t, ok)
+
+	_, ok = zero.LoadKey("a")
+	require.False(t, ok)
+}
+
+func TestBiMap_Delete(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		del      func(m *BiMap[int, string]) bool
+		m        func() *BiMap[int, string]
+		expOk    bool
+		expPairs []pair
+	}{
+		{
+			name: "zero map",
+			del: func(m *BiMap[int, string]) bool {
+				return m.Delete(1)
+			},
+			m: func() *BiMap[int, string] {
+				return &BiMap[int, string]{}
+			},
+		},
+		{
+			name: "by key",
+			del: func(m *BiMap[int, string]) bool {
+				return m.Delete(1)
+			},
+			m: func() *BiMap[int, string] {
+				return newBiMap(pair{1, "a"}, pair{2, "b"})
+			},
+			expOk:    true,
+			expPairs: []pair{{2, "b"}},
+		},
+		{
+			name: "by value",
+			del: func(m *BiMap[int, string]) bool {
+				return m.DeleteValue("b")
+			},
+			m: func() *BiMap[int, string] {
+				return newBiMap(pair{1, "a"}, pair{2, "b"})
+			},
+			expOk:    true,
+			expPairs: []pair{{1, "a"}},
+		},
+		{
+			name: "not found",
+			del: func(m *BiMap[int, string]) bool {
+				return m.DeleteValue("z")
+			},
+			m: func() *BiMap[int, string] {
+				return newBiMap(pair{1, "a"})
+			},
+			expPairs: []pair{{1, "a"}},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := tc.m()
+			require.Equal(t, tc.expOk, tc.del(m))
+			require.Equal(t, tc.expPairs, pairs(m))
+			checkInverse(t, m)
+		})
+	}
+}
+
+// newBiMap returns a map with the pairs.
+func newBiMap(ps ...pair) *BiMap[int, string] {
+	m := NewPresized[int, string](len(ps))
+	for _, p := range ps {
+		m.Store(p.k, p.v)
+	}
+
+	return m
+}
+
+// pairs returns the pairs of the map in order.
+func pairs(m *BiMap[int, string]) []pair {
+	var ps []pair
+	for it := m.Iter(); it.Next(); {
+		ps = append(ps, pair{it.Key(), it.Val()})
+	}
+
+	return ps
+}
+
+// checkInverse checks that both directions of the map agree.
+func checkInverse(t *testing.T, m *BiMap[int, string]) {
+	require.Equal(t, len(m.keys), len(m.vals))
+
+	for k, n := range m.keys {
+		require.Equal(t, k, n.Val().Key)
+		require.Same(t, n, m.vals[n.Val().Val])
+	}
+}
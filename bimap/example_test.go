@@ -0,0 +1,25 @@
+package bimap_test
+
+import (
+	"fmt"
+
+	"github.com/glebziz/containers/bimap"
+)
+
+func ExampleNew() {
+	m := bimap.New[int, string]()
+
+	m.Store(1, "alice")
+	m.Store(2, "bob")
+
+	name, _ := m.Load(1)
+	id, _ := m.LoadKey("bob")
+	fmt.Println(name, id)
+
+	err := m.TryStore(3, "alice")
+	fmt.Println(err)
+
+	// Output:
+	// alice 2
+	// bimap: value is already mapped to another key
+}
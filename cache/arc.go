@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"github.com/glebziz/containers/internal/node"
+	"github.com/glebziz/containers/omap"
+)
+
+// ARC represents an adaptive replacement cache.
+//
+// Resident entries are split between the recency queue T1 and the frequency queue T2,
+// and the keys of entries evicted from them are remembered in the ghost queues B1 and B2.
+// Hits in the ghost queues adapt the target size of T1, so the cache balances
+// between recency and frequency and resists scans.
+// All operations have O(1) complexity.
+type ARC[K comparable, V any] struct {
+	base[K, V]
+	t1 *omap.OMap[K, V]
+	t2 *omap.OMap[K, V]
+	b1 *omap.OMap[K, struct{}]
+	b2 *omap.OMap[K, struct{}]
+
+	p int
+}
+
+// NewARC returns an initialized ARC cache with the capacity.
+// T1 and T2 share one pool of nodes and B1 and B2 share another, both presized for the capacity.
+// If the capacity is less than one, the cache holds one entry.
+func NewARC[K comparable, V any](capacity int) *ARC[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	resident := node.NewPoolPresized[node.Entry[K, V]](capacity)
+	ghosts := node.NewPoolPresized[node.Entry[K, struct{}]](capacity)
+
+	c := &ARC[K, V]{
+		t1: omap.NewWithPool(resident),
+		t2: omap.NewWithPool(resident),
+		b1: omap.NewWithPool(ghosts),
+		b2: omap.NewWithPool(ghosts),
+	}
+	c.init(capacity, []*omap.OMap[K, V]{c.t1, c.t2}, []*omap.OMap[K, struct{}]{c.b1, c.b2})
+
+	return c
+}
+
+// Get returns the value by key and moves it to the most recently used position of T2.
+func (c *ARC[K, V]) Get(key K) (val V, ok bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	q, val, ok := c.lookup(key)
+	if !ok {
+		return val, false
+	}
+
+	q.Delete(key)
+	c.t2.Store(key, val)
+
+	return val, true
+}
+
+// Set stores the value by key.
+// A new key is added to T1, an existing or remembered key is moved to T2.
+func (c *ARC[K, V]) Set(key K, val V) {
+	c.m.Lock()
+
+	var (
+		e       entry[K, V]
+		evicted bool
+	)
+
+	if q, _, ok := c.find(key); ok {
+		q.Delete(key)
+		c.t2.Store(key, val)
+		c.unlock(e, evicted)
+
+		return
+	}
+
+	_, inB1 := c.b1.Load(key)
+	_, inB2 := c.b2.Load(key)
+
+	switch {
+	case inB1:
+		c.p = min(c.capacity, c.p+max(1, c.b2.Len()/c.b1.Len()))
+		c.b1.Delete(key)
+		e, evicted = c.replace(false)
+		c.t2.Store(key, val)
+	case inB2:
+		c.p = max(0, c.p-max(1, c.b1.Len()/c.b2.Len()))
+		c.b2.Delete(key)
+		e, evicted = c.replace(true)
+		c.t2.Store(key, val)
+	default:
+		e, evicted = c.admit()
+		c.t1.Store(key, val)
+	}
+
+	c.unlock(e, evicted)
+}
+
+// admit frees space for a new key according to the sizes of the queues.
+func (c *ARC[K, V]) admit() (entry[K, V], bool) {
+	t1, t2, b1, b2 := c.t1.Len(), c.t2.Len(), c.b1.Len(), c.b2.Len()
+
+	if t1+b1 >= c.capacity {
+		if t1 < c.capacity {
+			forget(c.b1)
+			return c.replace(false)
+		}
+
+		return c.evict(c.t1)
+	}
+
+	if total := t1 + t2 + b1 + b2; total >= c.capacity {
+		if total >= 2*c.capacity && b2 > 0 {
+			forget(c.b2)
+		}
+
+		return c.replace(false)
+	}
+
+	return entry[K, V]{}, false
+}
+
+// replace moves the least recently used entry of T1 or T2 to its ghost queue if the cache is full.
+func (c *ARC[K, V]) replace(inB2 bool) (entry[K, V], bool) {
+	t1, t2 := c.t1.Len(), c.t2.Len()
+	if t1+t2 < c.capacity {
+		return entry[K, V]{}, false
+	}
+
+	if t1 > 0 && (t1 > c.p || (inB2 && t1 == c.p) || t2 == 0) {
+		return c.demote(c.t1, c.b1)
+	}
+
+	return c.demote(c.t2, c.b2)
+}
@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func BenchmarkLFU_Set(b *testing.B) {
+	const (
+		capacity = 1 << 10
+	)
+
+	b.ReportAllocs()
+
+	c := NewLFU[int, int](capacity)
+
+	for i := 0; i < capacity; i++ {
+		c.Set(i, i)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		c.Set(i, i)
+	}
+}
+
+func BenchmarkLFU_Get(b *testing.B) {
+	const (
+		capacity = 1 << 10
+	)
+
+	b.ReportAllocs()
+
+	c := NewLFU[int, int](capacity)
+
+	for i := 0; i < capacity; i++ {
+		c.Set(i, i)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		c.Get(i % capacity)
+	}
+}
+
+// scanTrace returns a synthetic trace of keys with a skewed hot set
+// interleaved with periodic full scans over a large range of cold keys.
+func scanTrace(n, hot, scan int) []int {
+	r := rand.New(rand.NewSource(1))
+	z := rand.NewZipf(r, 1.2, 1, uint64(hot-1))
+
+	trace := make([]int, 0, n)
+	for len(trace) < n {
+		for i := 0; i < 4*scan && len(trace) < n; i++ {
+			trace = append(trace, int(z.Uint64()))
+		}
+
+		for i := 0; i < scan && len(trace) < n; i++ {
+			trace = append(trace, hot+r.Intn(100*scan))
+		}
+	}
+
+	return trace
+}
+
+func BenchmarkCache_HitRatio(b *testing.B) {
+	const (
+		capacity = 1 << 10
+	)
+
+	trace := scanTrace(1<<18, 4*capacity, 2*capacity)
+
+	for _, p := range []Policy{LRUPolicy, LFUPolicy, TwoQueuePolicy, ARCPolicy} {
+		p := p
+		b.Run(p.String(), func(b *testing.B) {
+			b.ReportAllocs()
+
+			c := New[int, int](p, capacity)
+
+			for i := 0; i < b.N; i++ {
+				k := trace[i%len(trace)]
+				if _, ok := c.Get(k); !ok {
+					c.Set(k, k)
+				}
+			}
+
+			b.ReportMetric(c.Stats().HitRatio()*100, "hit%")
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Package cache implements caches with a pool of nodes,
+// so promotions and evictions do not allocate memory after warmup.
+//
+// All caches implement the Cache interface,
+// so the eviction policy can be selected by configuration:
+//
+//	p, err := cache.ParsePolicy("arc")
+//	if err != nil {
+//		// handle error
+//	}
+//
+//	c := cache.New[string, int](p, 1024)
+package cache
+
+import (
+	"fmt"
+)
+
+// Cache is the common interface of the caches with different eviction policies.
+type Cache[K comparable, V any] interface {
+	// Get returns the value by key and counts a hit or a miss.
+	Get(key K) (V, bool)
+	// Peek returns the value by key without changing the cache state and the stats.
+	Peek(key K) (V, bool)
+	// Set stores the value by key evicting entries according to the policy.
+	Set(key K, val V)
+	// Delete removes the value by key and reports whether it was present.
+	Delete(key K) bool
+	// Len returns the number of cached entries.
+	Len() int
+	// Cap returns the capacity of the cache.
+	Cap() int
+	// Stats returns the counters of the cache operations.
+	Stats() Stats
+	// OnEvict sets the function called for every evicted entry.
+	OnEvict(fn func(key K, val V))
+}
+
+// Policy is the eviction policy of a cache.
+type Policy int
+
+const (
+	// LRUPolicy evicts the least recently used entry.
+	LRUPolicy Policy = iota
+	// LFUPolicy evicts the least frequently used entry.
+	LFUPolicy
+	// TwoQueuePolicy is the scan resistant 2Q policy.
+	TwoQueuePolicy
+	// ARCPolicy is the scan resistant adaptive replacement cache policy.
+	ARCPolicy
+)
+
+// String returns the name of the policy.
+func (p Policy) String() string {
+	switch p {
+	case LRUPolicy:
+		return "lru"
+	case LFUPolicy:
+		return "lfu"
+	case TwoQueuePolicy:
+		return "2q"
+	case ARCPolicy:
+		return "arc"
+	}
+
+	return fmt.Sprintf("Policy(%d)", int(p))
+}
+
+// ParsePolicy returns the policy by its name.
+func ParsePolicy(name string) (Policy, error) {
+	for _, p := range []Policy{LRUPolicy, LFUPolicy, TwoQueuePolicy, ARCPolicy} {
+		if p.String() == name {
+			return p, nil
+		}
+	}
+
+	return 0, fmt.Errorf("cache: unknown policy %q", name)
+}
+
+// New returns an initialized cache with the policy and the capacity.
+// An unknown policy falls back to LRU.
+func New[K comparable, V any](p Policy, capacity int) Cache[K, V] {
+	switch p {
+	case LFUPolicy:
+		return NewLFU[K, V](capacity)
+	case TwoQueuePolicy:
+		return New2Q[K, V](capacity)
+	case ARCPolicy:
+		return NewARC[K, V](capacity)
+	}
+
+	return NewLRU[K, V](capacity)
+}
+
+// Stats are the counters of cache operations.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// HitRatio returns the ratio of hits to all lookups or zero if there were no lookups.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(total)
+}
@@ -0,0 +1,209 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/glebziz/containers/omap"
+)
+
+var policies = []Policy{LRUPolicy, LFUPolicy, TwoQueuePolicy, ARCPolicy}
+
+func TestCache(t *testing.T) {
+	for _, p := range policies {
+		p := p
+		t.Run(p.String(), func(t *testing.T) {
+			t.Parallel()
+
+			c := New[int, int](p, 4)
+			require.Equal(t, 4, c.Cap())
+
+			var evicted []int
+			c.OnEvict(func(k, v int) {
+				require.Equal(t, k*10, v)
+				evicted = append(evicted, k)
+			})
+
+			for i := 0; i < 4; i++ {
+				c.Set(i, i*10)
+			}
+
+			require.Equal(t, 4, c.Len())
+			require.Empty(t, evicted)
+
+			v, ok := c.Get(2)
+			require.True(t, ok)
+			require.Equal(t, 20, v)
+
+			_, ok = c.Get(10)
+			require.False(t, ok)
+
+			for i := 4; i < 20; i++ {
+				c.Set(i, i*10)
+				require.LessOrEqual(t, c.Len(), 4)
+			}
+
+			require.Equal(t, 4, c.Len())
+			require.Len(t, evicted, 16)
+			require.Equal(t, Stats{Hits: 1, Misses: 1, Evictions: 16}, c.Stats())
+
+			c.Set(19, 191)
+			v, ok = c.Peek(19)
+			require.True(t, ok)
+			require.Equal(t, 191, v)
+
+			require.True(t, c.Delete(19))
+			require.False(t, c.Delete(19))
+			require.Equal(t, 3, c.Len())
+
+			_, ok = c.Peek(19)
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	require.IsType(t, &LRU[int, int]{}, New[int, int](LRUPolicy, 1))
+	require.IsType(t, &LFU[int, int]{}, New[int, int](LFUPolicy, 1))
+	require.IsType(t, &TwoQueue[int, int]{}, New[int, int](TwoQueuePolicy, 1))
+	require.IsType(t, &ARC[int, int]{}, New[int, int](ARCPolicy, 1))
+	require.IsType(t, &LRU[int, int]{}, New[int, int](Policy(100), 1))
+}
+
+func TestParsePolicy(t *testing.T) {
+	for _, p := range policies {
+		parsed, err := ParsePolicy(p.String())
+		require.NoError(t, err)
+		require.Equal(t, p, parsed)
+	}
+
+	_, err := ParsePolicy("fifo")
+	require.Error(t, err)
+	require.Equal(t, "Policy(100)", Policy(100).String())
+}
+
+func TestLRU_Get(t *testing.T) {
+	c := NewLRU[int, int](2)
+	c.Set(1, 10)
+	c.Set(2, 20)
+	c.Get(1)
+	c.Set(3, 30)
+
+	_, ok := c.Peek(2)
+	require.False(t, ok)
+
+	_, ok = c.Peek(1)
+	require.True(t, ok)
+}
+
+func TestTwoQueue_ScanResistance(t *testing.T) {
+	c := New2Q[int, int](8)
+
+	// Hot keys are promoted to Am after being remembered in A1out.
+	for i := 0; i < 12; i++ {
+		c.Set(i, i)
+	}
+
+	for i := 0; i < 4; i++ {
+		c.Set(i, i)
+	}
+
+	hot := c.am.Len()
+	require.Equal(t, 4, hot)
+
+	for i := 100; i < 200; i++ {
+		c.Set(i, i)
+	}
+
+	require.Equal(t, hot, c.am.Len())
+	require.LessOrEqual(t, c.out.Len(), c.out.Cap())
+	require.Equal(t, 8, c.Len())
+}
+
+func TestTwoQueue_Set(t *testing.T) {
+	c := New2Q[int, int](4)
+	c.Set(1, 10)
+	c.Set(2, 20)
+	c.Set(1, 11)
+	require.True(t, has(c.in, 1))
+
+	// An updated key of A1in keeps its position in the FIFO.
+	k, v, _ := c.in.Front()
+	require.Equal(t, [2]int{1, 11}, [2]int{k, v})
+	c.Delete(2)
+
+	for i := 2; i < 6; i++ {
+		c.Set(i, i)
+	}
+
+	require.True(t, has(c.out, 1))
+
+	c.Set(1, 12)
+	require.True(t, has(c.am, 1))
+
+	v, ok := c.Get(1)
+	require.True(t, ok)
+	require.Equal(t, 12, v)
+}
+
+func TestARC_Adaptation(t *testing.T) {
+	c := NewARC[int, int](4)
+
+	for i := 0; i < 4; i++ {
+		c.Set(i, i)
+	}
+
+	c.Get(0)
+	c.Get(1)
+	require.Equal(t, 2, c.t1.Len())
+	require.Equal(t, 2, c.t2.Len())
+
+	c.Set(4, 4)
+	require.True(t, has(c.b1, 2))
+	require.Zero(t, c.p)
+
+	// A hit in B1 grows the target size of T1.
+	c.Set(2, 2)
+	require.Equal(t, 1, c.p)
+	require.True(t, has(c.t2, 2))
+	require.Equal(t, 4, c.Len())
+
+	for i := 100; i < 200; i++ {
+		c.Set(i, i)
+	}
+
+	require.LessOrEqual(t, c.t1.Len()+c.t2.Len()+c.b1.Len()+c.b2.Len(), 8)
+	require.Equal(t, 4, c.Len())
+}
+
+func TestCache_SharedPools(t *testing.T) {
+	const capacity = 16
+
+	q := New2Q[int, int](capacity)
+	a := NewARC[int, int](capacity)
+	for i := 0; i < 8*capacity; i++ {
+		for _, c := range []Cache[int, int]{q, a} {
+			c.Set(i%(2*capacity), i)
+			c.Get(i % capacity)
+		}
+	}
+
+	// The queues of a cache take their nodes from one pool, so every queue reports the stats of the shared pool.
+	require.Equal(t, q.in.Stats(), q.am.Stats())
+	require.Equal(t, q.in.Len()+q.am.Len(), q.in.Stats().Live)
+	require.Equal(t, capacity, q.in.Stats().Capacity)
+
+	require.Equal(t, a.t1.Stats(), a.t2.Stats())
+	require.Equal(t, a.t1.Len()+a.t2.Len(), a.t1.Stats().Live)
+	require.Equal(t, capacity, a.t1.Stats().Capacity)
+
+	require.Equal(t, a.b1.Stats(), a.b2.Stats())
+	require.Equal(t, a.b1.Len()+a.b2.Len(), a.b1.Stats().Live)
+}
+
+// has reports whether the queue holds the key.
+func has[V any](q *omap.OMap[int, V], key int) bool {
+	_, ok := q.Load(key)
+	return ok
+}
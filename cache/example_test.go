@@ -0,0 +1,26 @@
+package cache_test
+
+import (
+	"fmt"
+
+	"github.com/glebziz/containers/cache"
+)
+
+func ExampleNewLFU() {
+	c := cache.NewLFU[string, int](2)
+
+	c.OnEvict(func(key string, val int) {
+		fmt.Println("evicted", key)
+	})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")
+	c.Set("c", 3)
+
+	fmt.Println(c.Stats().Hits)
+
+	// Output:
+	// evicted b
+	// 1
+}
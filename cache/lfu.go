@@ -0,0 +1,260 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/glebziz/containers/internal/node"
+)
+
+var (
+	_ Cache[int, int] = (*LRU[int, int])(nil)
+	_ Cache[int, int] = (*LFU[int, int])(nil)
+	_ Cache[int, int] = (*TwoQueue[int, int])(nil)
+	_ Cache[int, int] = (*ARC[int, int])(nil)
+)
+
+// LFU represents a least frequently used cache.
+// Entries with equal frequency are evicted in least recently used order.
+// All operations have O(1) complexity.
+type LFU[K comparable, V any] struct {
+	data    map[K]*node.Node[lfuEntry[K, V]]
+	buckets node.Node[lfuBucket[K, V]]
+	entries *node.Pool[lfuEntry[K, V]]
+	pool    *node.Pool[lfuBucket[K, V]]
+
+	capacity int
+	stats    Stats
+	onEvict  func(key K, val V)
+
+	m sync.Mutex
+}
+
+// lfuEntry is a cached value with the frequency bucket it belongs to.
+type lfuEntry[K comparable, V any] struct {
+	key    K
+	val    V
+	bucket *node.Node[lfuBucket[K, V]]
+}
+
+// Reset resets the value if the pointer to the value implements node.Resetter.
+// It allows the pool to reset the values of the cache.
+func (e *lfuEntry[K, V]) Reset() {
+	if r, ok := any(&e.val).(node.Resetter); ok {
+		r.Reset()
+	}
+}
+
+// lfuBucket is a list of entries with the same access frequency
+// ordered from the least to the most recently used.
+// The sentinel of the list is embedded, so a bucket is taken from the pool without taking an entry node,
+// and must be used in place through Ref.
+type lfuBucket[K comparable, V any] struct {
+	freq uint64
+	root node.Node[lfuEntry[K, V]]
+}
+
+// NewLFU returns an initialized LFU cache with the capacity.
+// If the capacity is less than one, the cache holds one entry.
+// The pools of the entries and the buckets are presized,
+// so the promotions and the evictions do not allocate.
+func NewLFU[K comparable, V any](capacity int) *LFU[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	c := &LFU[K, V]{
+		data:     make(map[K]*node.Node[lfuEntry[K, V]], capacity),
+		entries:  node.NewPoolPresized[lfuEntry[K, V]](capacity),
+		pool:     node.NewPoolPresized[lfuBucket[K, V]](capacity + 1),
+		capacity: capacity,
+	}
+
+	c.buckets.SetNext(&c.buckets)
+	c.buckets.SetPrev(&c.buckets)
+
+	return c
+}
+
+// OnEvict sets the function called for every entry evicted from the cache.
+// The function is called after the cache is unlocked, so it may use the cache.
+func (c *LFU[K, V]) OnEvict(fn func(key K, val V)) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.onEvict = fn
+}
+
+// Len returns the number of entries of the cache.
+func (c *LFU[K, V]) Len() int {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return len(c.data)
+}
+
+// Cap returns the capacity of the cache.
+func (c *LFU[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Stats returns the counters of the cache operations.
+func (c *LFU[K, V]) Stats() Stats {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return c.stats
+}
+
+// Get returns the value by key and increments its frequency.
+func (c *LFU[K, V]) Get(key K) (val V, ok bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	n, ok := c.data[key]
+	if !ok {
+		c.stats.Misses++
+		return val, false
+	}
+
+	c.stats.Hits++
+	c.touch(n)
+
+	return n.Val().val, true
+}
+
+// Peek returns the value by key without changing its frequency and the stats.
+func (c *LFU[K, V]) Peek(key K) (val V, ok bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	n, ok := c.data[key]
+	return n.Val().val, ok
+}
+
+// Set stores the value by key.
+// A new key evicts the least frequently used entry if the cache is full,
+// an existing key is updated and its frequency is incremented.
+func (c *LFU[K, V]) Set(key K, val V) {
+	c.m.Lock()
+
+	if n, ok := c.data[key]; ok {
+		e := n.Val()
+		e.val = val
+		n.SetVal(e)
+		c.touch(n)
+		c.m.Unlock()
+
+		return
+	}
+
+	var (
+		evicted lfuEntry[K, V]
+		ok      bool
+	)
+	if len(c.data) >= c.capacity {
+		evicted, ok = c.evict()
+	}
+
+	b := c.buckets.Next()
+	if b == &c.buckets || b.Val().freq != 1 {
+		b = c.newBucket(1, &c.buckets)
+	}
+
+	n := c.entries.Pop()
+	n.SetVal(lfuEntry[K, V]{
+		key:    key,
+		val:    val,
+		bucket: b,
+	})
+	b.Ref().root.Prev().Insert(n)
+	c.data[key] = n
+
+	fn := c.onEvict
+	c.m.Unlock()
+
+	if ok && fn != nil {
+		fn(evicted.key, evicted.val)
+	}
+}
+
+// Delete removes the value by key and reports whether it was present.
+func (c *LFU[K, V]) Delete(key K) bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	n, ok := c.data[key]
+	if ok {
+		c.remove(n)
+	}
+
+	return ok
+}
+
+// touch moves the entry to the bucket with the next frequency.
+func (c *LFU[K, V]) touch(n *node.Node[lfuEntry[K, V]]) {
+	e := n.Val()
+	b := e.bucket
+	freq := b.Val().freq + 1
+
+	next := b.Next()
+	if next == &c.buckets || next.Val().freq != freq {
+		next = c.newBucket(freq, b)
+	}
+
+	n.Remove()
+	c.release(b)
+
+	e.bucket = next
+	n.SetVal(e)
+	next.Ref().root.Prev().Insert(n)
+}
+
+// evict removes the least recently used entry of the least frequency bucket.
+func (c *LFU[K, V]) evict() (lfuEntry[K, V], bool) {
+	b := c.buckets.Next()
+	if b == &c.buckets {
+		return lfuEntry[K, V]{}, false
+	}
+
+	n := b.Ref().root.Next()
+	e := n.Val()
+	c.remove(n)
+	c.stats.Evictions++
+
+	return e, true
+}
+
+// remove removes the entry from the cache.
+func (c *LFU[K, V]) remove(n *node.Node[lfuEntry[K, V]]) {
+	e := n.Val()
+	delete(c.data, e.key)
+	n.Remove()
+	c.entries.Push(n)
+	c.release(e.bucket)
+}
+
+// newBucket inserts a new empty bucket with the frequency after the node at.
+// A live bucket holds at least one entry, except the one created by touch before the previous is released,
+// so there are at most capacity+1 buckets.
+func (c *LFU[K, V]) newBucket(freq uint64, at *node.Node[lfuBucket[K, V]]) *node.Node[lfuBucket[K, V]] {
+	b := c.pool.Pop()
+
+	v := b.Ref()
+	v.freq = freq
+	v.root.SetNext(&v.root)
+	v.root.SetPrev(&v.root)
+	at.Insert(b)
+
+	return b
+}
+
+// release removes the bucket if it has no entries.
+func (c *LFU[K, V]) release(b *node.Node[lfuBucket[K, V]]) {
+	root := &b.Ref().root
+	if root.Next() != root {
+		return
+	}
+
+	b.Remove()
+	c.pool.Push(b)
+}
@@ -0,0 +1,198 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type pair struct {
+	k int
+	v int
+}
+
+func TestLFU_Set(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		capacity   int
+		ops        func(c *LFU[int, int])
+		expEvicted []pair
+		expKeys    []int
+	}{
+		{
+			name:     "without eviction",
+			capacity: 3,
+			ops: func(c *LFU[int, int]) {
+				c.Set(1, 10)
+				c.Set(2, 20)
+			},
+			expKeys: []int{1, 2},
+		},
+		{
+			name:     "least frequently used is evicted",
+			capacity: 2,
+			ops: func(c *LFU[int, int]) {
+				c.Set(1, 10)
+				c.Set(2, 20)
+				c.Get(1)
+				c.Set(3, 30)
+			},
+			expEvicted: []pair{{2, 20}},
+			expKeys:    []int{1, 3},
+		},
+		{
+			name:     "least recently used with same frequency is evicted",
+			capacity: 2,
+			ops: func(c *LFU[int, int]) {
+				c.Set(1, 10)
+				c.Set(2, 20)
+				c.Get(2)
+				c.Get(1)
+				c.Set(3, 30)
+			},
+			expEvicted: []pair{{2, 20}},
+			expKeys:    []int{1, 3},
+		},
+		{
+			name:     "update increments frequency",
+			capacity: 2,
+			ops: func(c *LFU[int, int]) {
+				c.Set(1, 10)
+				c.Set(2, 20)
+				c.Set(1, 11)
+				c.Set(3, 30)
+				c.Set(4, 40)
+			},
+			expEvicted: []pair{{2, 20}, {3, 30}},
+			expKeys:    []int{1, 4},
+		},
+		{
+			name:     "invalid capacity",
+			capacity: 0,
+			ops: func(c *LFU[int, int]) {
+				c.Set(1, 10)
+				c.Set(2, 20)
+			},
+			expEvicted: []pair{{1, 10}},
+			expKeys:    []int{2},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := NewLFU[int, int](tc.capacity)
+
+			var evicted []pair
+			c.OnEvict(func(k, v int) {
+				evicted = append(evicted, pair{k, v})
+			})
+
+			tc.ops(c)
+			require.Equal(t, tc.expEvicted, evicted)
+			require.Equal(t, len(tc.expKeys), c.Len())
+			require.Equal(t, uint64(len(tc.expEvicted)), c.Stats().Evictions)
+
+			for _, k := range tc.expKeys {
+				_, ok := c.Peek(k)
+				require.True(t, ok)
+			}
+		})
+	}
+}
+
+func TestLFU_Get(t *testing.T) {
+	c := NewLFU[int, int](2)
+	c.Set(1, 10)
+
+	v, ok := c.Get(1)
+	require.True(t, ok)
+	require.Equal(t, 10, v)
+
+	v, ok = c.Get(2)
+	require.False(t, ok)
+	require.Zero(t, v)
+
+	require.Equal(t, Stats{Hits: 1, Misses: 1}, c.Stats())
+	require.Equal(t, 0.5, c.Stats().HitRatio())
+	require.Equal(t, 2, c.Cap())
+}
+
+func TestLFU_Delete(t *testing.T) {
+	c := NewLFU[int, int](2)
+	c.Set(1, 10)
+	c.Set(2, 20)
+	c.Get(1)
+
+	require.True(t, c.Delete(1))
+	require.False(t, c.Delete(1))
+	require.Equal(t, 1, c.Len())
+
+	_, ok := c.Peek(1)
+	require.False(t, ok)
+
+	require.Equal(t, &c.buckets, c.buckets.Next().Next())
+}
+
+type resetVal struct {
+	resets *int
+}
+
+func (v *resetVal) Reset() {
+	*v.resets++
+}
+
+func TestLFU_ResetValues(t *testing.T) {
+	c := NewLFU[int, resetVal](1)
+
+	resets := 0
+	c.Set(1, resetVal{resets: &resets})
+	c.Set(2, resetVal{resets: &resets})
+	require.Equal(t, 1, resets)
+
+	c.Delete(2)
+	require.Equal(t, 2, resets)
+}
+
+func TestLFU_Buckets(t *testing.T) {
+	c := NewLFU[int, int](3)
+	c.Set(1, 10)
+	c.Set(2, 20)
+	c.Set(3, 30)
+	c.Get(1)
+	c.Get(1)
+	c.Get(2)
+
+	var freqs []uint64
+	for b := c.buckets.Next(); b != &c.buckets; b = b.Next() {
+		freqs = append(freqs, b.Val().freq)
+	}
+
+	require.Equal(t, []uint64{1, 2, 3}, freqs)
+}
+
+func TestLFU_NoAllocs(t *testing.T) {
+	const capacity = 64
+
+	c := NewLFU[int, int](capacity)
+
+	key := 0
+	ops := func() {
+		for i := 0; i < 2*capacity; i++ {
+			c.Set(key, key)
+			for j := 0; j < i%capacity; j++ {
+				c.Get(key)
+			}
+
+			key++
+		}
+	}
+
+	require.Zero(t, testing.AllocsPerRun(10, ops))
+	require.Equal(t, capacity, c.Len())
+}
+
+func TestStats_HitRatio(t *testing.T) {
+	require.Zero(t, Stats{}.HitRatio())
+	require.Equal(t, 0.75, Stats{Hits: 3, Misses: 1}.HitRatio())
+}
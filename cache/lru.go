@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"github.com/glebziz/containers/internal/node"
+	"github.com/glebziz/containers/omap"
+)
+
+// LRU represents a least recently used cache.
+// All operations have O(1) complexity.
+type LRU[K comparable, V any] struct {
+	base[K, V]
+	q *omap.OMap[K, V]
+}
+
+// NewLRU returns an initialized LRU cache with the capacity.
+// If the capacity is less than one, the cache holds one entry.
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	c := &LRU[K, V]{
+		q: omap.NewWithPool(node.NewPoolPresized[node.Entry[K, V]](capacity)),
+	}
+	c.init(capacity, []*omap.OMap[K, V]{c.q}, nil)
+
+	return c
+}
+
+// Get returns the value by key and marks it as the most recently used.
+func (c *LRU[K, V]) Get(key K) (val V, ok bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	_, val, ok = c.lookup(key)
+	if ok {
+		c.q.MoveToBack(key)
+	}
+
+	return val, ok
+}
+
+// Set stores the value by key as the most recently used.
+// A new key evicts the least recently used entry if the cache is full.
+func (c *LRU[K, V]) Set(key K, val V) {
+	c.m.Lock()
+
+	var (
+		e       entry[K, V]
+		evicted bool
+	)
+	if _, ok := c.q.Load(key); !ok && c.q.Len() >= c.capacity {
+		e, evicted = c.evict(c.q)
+	}
+
+	c.q.Store(key, val)
+	c.unlock(e, evicted)
+}
@@ -0,0 +1,162 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/glebziz/containers/omap"
+)
+
+// entry is an evicted key with its value.
+type entry[K comparable, V any] struct {
+	key K
+	val V
+}
+
+// base is the common part of the caches built from several ordered map queues.
+// Every queue is an omap.OMap ordered from the least to the most recently added key,
+// a key is in at most one queue at a time. The ghost queues remember the keys of the evicted entries without values.
+// The resident queues of a cache share one pool of nodes, so do its ghost queues,
+// and the cache lock serializes all operations on the shared pools.
+type base[K comparable, V any] struct {
+	resident []*omap.OMap[K, V]
+	ghosts   []*omap.OMap[K, struct{}]
+	capacity int
+	stats    Stats
+	onEvict  func(key K, val V)
+
+	m sync.Mutex
+}
+
+// init initializes the base with the capacity and the queues of the resident entries and the ghost keys.
+func (c *base[K, V]) init(capacity int, resident []*omap.OMap[K, V], ghosts []*omap.OMap[K, struct{}]) {
+	c.capacity = capacity
+	c.resident = resident
+	c.ghosts = ghosts
+}
+
+// OnEvict sets the function called for every entry evicted from the cache.
+// The function is called after the cache is unlocked, so it may use the cache.
+func (c *base[K, V]) OnEvict(fn func(key K, val V)) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.onEvict = fn
+}
+
+// Cap returns the capacity of the cache.
+func (c *base[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Stats returns the counters of the cache operations.
+func (c *base[K, V]) Stats() Stats {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return c.stats
+}
+
+// Len returns the number of entries of the cache.
+func (c *base[K, V]) Len() int {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	n := 0
+	for _, q := range c.resident {
+		n += q.Len()
+	}
+
+	return n
+}
+
+// Peek returns the value by key without changing the cache state and the stats.
+func (c *base[K, V]) Peek(key K) (val V, ok bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	_, val, ok = c.find(key)
+	return val, ok
+}
+
+// Delete removes the value by key and reports whether it was present.
+// A remembered key is forgotten, but it is not reported as present.
+func (c *base[K, V]) Delete(key K) bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if q, _, ok := c.find(key); ok {
+		q.Delete(key)
+		return true
+	}
+
+	for _, g := range c.ghosts {
+		g.Delete(key)
+	}
+
+	return false
+}
+
+// find returns the resident queue holding the key with its value.
+func (c *base[K, V]) find(key K) (*omap.OMap[K, V], V, bool) {
+	for _, q := range c.resident {
+		if val, ok := q.Load(key); ok {
+			return q, val, true
+		}
+	}
+
+	var val V
+	return nil, val, false
+}
+
+// lookup returns the resident queue holding the key with its value and counts a hit or a miss.
+func (c *base[K, V]) lookup(key K) (*omap.OMap[K, V], V, bool) {
+	q, val, ok := c.find(key)
+	if ok {
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
+	}
+
+	return q, val, ok
+}
+
+// evict removes the least recently added entry of the resident queue and returns it as evicted.
+func (c *base[K, V]) evict(q *omap.OMap[K, V]) (entry[K, V], bool) {
+	key, val, ok := q.Front()
+	if !ok {
+		return entry[K, V]{}, false
+	}
+
+	q.Delete(key)
+	c.stats.Evictions++
+
+	return entry[K, V]{key: key, val: val}, true
+}
+
+// demote evicts the least recently added entry of the resident queue q
+// and remembers its key at the back of the ghost queue g.
+func (c *base[K, V]) demote(q *omap.OMap[K, V], g *omap.OMap[K, struct{}]) (entry[K, V], bool) {
+	e, ok := c.evict(q)
+	if ok {
+		g.Store(e.key, struct{}{})
+	}
+
+	return e, ok
+}
+
+// forget removes the least recently added key of the ghost queue.
+func forget[K comparable](g *omap.OMap[K, struct{}]) {
+	if key, _, ok := g.Front(); ok {
+		g.Delete(key)
+	}
+}
+
+// unlock unlocks the cache and calls the eviction function if an entry was evicted.
+func (c *base[K, V]) unlock(e entry[K, V], evicted bool) {
+	fn := c.onEvict
+	c.m.Unlock()
+
+	if evicted && fn != nil {
+		fn(e.key, e.val)
+	}
+}
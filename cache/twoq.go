@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"github.com/glebziz/containers/internal/node"
+	"github.com/glebziz/containers/omap"
+)
+
+// TwoQueue represents a cache with the full 2Q policy.
+//
+// New entries are added to the FIFO queue A1in. Entries evicted from A1in are remembered
+// without values in the ghost FIFO queue A1out, and only entries stored again while in A1out
+// are promoted to the LRU queue Am. So a single scan over many keys does not evict the hot entries of Am.
+// All operations have O(1) complexity.
+type TwoQueue[K comparable, V any] struct {
+	base[K, V]
+	in  *omap.OMap[K, V]
+	out *omap.OMap[K, struct{}]
+	am  *omap.OMap[K, V]
+
+	kin int
+}
+
+// New2Q returns an initialized 2Q cache with the capacity.
+// A quarter of the capacity is reserved for A1in,
+// and the keys of half of the capacity are remembered in A1out.
+// A1in and Am share one pool of nodes presized for the capacity.
+// If the capacity is less than one, the cache holds one entry.
+func New2Q[K comparable, V any](capacity int) *TwoQueue[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	pool := node.NewPoolPresized[node.Entry[K, V]](capacity)
+
+	c := &TwoQueue[K, V]{
+		in:  omap.NewWithPool(pool),
+		out: omap.NewBounded[K, struct{}](max(1, capacity/2), omap.DropOldest),
+		am:  omap.NewWithPool(pool),
+		kin: max(1, capacity/4),
+	}
+	c.init(capacity, []*omap.OMap[K, V]{c.in, c.am}, []*omap.OMap[K, struct{}]{c.out})
+
+	return c
+}
+
+// Get returns the value by key.
+// An entry of Am is marked as the most recently used.
+func (c *TwoQueue[K, V]) Get(key K) (val V, ok bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	q, val, ok := c.lookup(key)
+	if ok && q == c.am {
+		c.am.MoveToBack(key)
+	}
+
+	return val, ok
+}
+
+// Set stores the value by key.
+// A new key is added to A1in, a key remembered in A1out is promoted to Am.
+// An updated key stays in its queue, it keeps its position in the A1in FIFO
+// and becomes the most recent key of Am.
+func (c *TwoQueue[K, V]) Set(key K, val V) {
+	c.m.Lock()
+
+	if q, _, ok := c.find(key); ok {
+		if q == c.in {
+			q.Replace(key, val)
+		} else {
+			q.Store(key, val)
+		}
+		c.unlock(entry[K, V]{}, false)
+
+		return
+	}
+
+	_, promoted := c.out.Load(key)
+	if promoted {
+		c.out.Delete(key)
+	}
+
+	e, evicted := c.reclaim()
+
+	if promoted {
+		c.am.Store(key, val)
+	} else {
+		c.in.Store(key, val)
+	}
+
+	c.unlock(e, evicted)
+}
+
+// reclaim frees space for a new entry if the cache is full.
+// The key evicted from A1in is remembered in A1out, which forgets its oldest key when it is full.
+func (c *TwoQueue[K, V]) reclaim() (entry[K, V], bool) {
+	if c.in.Len()+c.am.Len() < c.capacity {
+		return entry[K, V]{}, false
+	}
+
+	if c.in.Len() > c.kin || c.am.Len() == 0 {
+		return c.demote(c.in, c.out)
+	}
+
+	return c.evict(c.am)
+}
@@ -0,0 +1,99 @@
+package clist
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/glebziz/containers/list"
+)
+
+func BenchmarkList_PushBack(b *testing.B) {
+	b.Run("list", func(b *testing.B) {
+		b.ReportAllocs()
+
+		l := list.New[int]()
+
+		for i := 0; i < b.N; i++ {
+			l.PushBack(i)
+		}
+	})
+
+	b.Run("compact list", func(b *testing.B) {
+		b.ReportAllocs()
+
+		l := New[int]()
+
+		for i := 0; i < b.N; i++ {
+			l.PushBack(i)
+		}
+	})
+}
+
+func BenchmarkList_Iter(b *testing.B) {
+	b.Run("list", func(b *testing.B) {
+		b.ReportAllocs()
+
+		l := list.NewPresized[int](b.N)
+		for i := 0; i < b.N; i++ {
+			l.PushBack(i)
+		}
+
+		b.ResetTimer()
+
+		for it := l.Iter(); it.Next(); {
+			_ = it.Val()
+		}
+	})
+
+	b.Run("compact list", func(b *testing.B) {
+		b.ReportAllocs()
+
+		l := NewPresized[int](b.N)
+		for i := 0; i < b.N; i++ {
+			l.PushBack(i)
+		}
+
+		b.ResetTimer()
+
+		for it := l.Iter(); it.Next(); {
+			_ = it.Val()
+		}
+	})
+}
+
+// BenchmarkList_GC measures the duration of a full garbage collection with a live list of N elements.
+func BenchmarkList_GC(b *testing.B) {
+	const (
+		N = 1 << 22
+	)
+
+	b.Run("list", func(b *testing.B) {
+		l := list.NewPresized[int](N)
+		for i := 0; i < N; i++ {
+			l.PushBack(i)
+		}
+
+		benchGC(b)
+		runtime.KeepAlive(l)
+	})
+
+	b.Run("compact list", func(b *testing.B) {
+		l := NewPresized[int](N)
+		for i := 0; i < N; i++ {
+			l.PushBack(i)
+		}
+
+		benchGC(b)
+		runtime.KeepAlive(l)
+	})
+}
+
+// benchGC runs a full garbage collection per operation, so ns/op is the GC duration.
+func benchGC(b *testing.B) {
+	runtime.GC()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+	}
+}
@@ -0,0 +1,356 @@
+// Package clist implements a compact doubly linked list with a pool of index-linked slots.
+//
+// The elements are linked by uint32 indexes instead of pointers,
+// so a list of a type without pointers holds no pointers per element
+// and is not scanned by the garbage collector.
+//
+// The list has the element API of list.List: the pushes and pops, Get, Set, Reverse, Rotate, Swap, Move
+// and the search functions. The bounded and sized lists, the hooks and the transactions of list.List
+// are not provided, so the slots hold only the values and the links, and Set returns no error.
+//
+// To iterate over a list (where l is a *List):
+//
+//	it := l.Iter()
+//	for it.Next() {
+//		// do something with it.Val()
+//	}
+package clist
+
+import (
+	"sync"
+
+	"github.com/glebziz/containers/internal/iter"
+	"github.com/glebziz/containers/internal/node"
+)
+
+// List represents a compact doubly linked list.
+// The zero value for List is an empty list ready to use.
+type List[T any] struct {
+	root uint32
+	pool *node.IndexPool[T]
+	m    sync.RWMutex
+	len  int
+}
+
+// New returns an initialized list.
+func New[T any]() *List[T] {
+	l := &List[T]{
+		pool: node.NewIndexPool[T](),
+	}
+	l.lazyInit()
+
+	return l
+}
+
+// NewPresized returns an initialized list with an allocated pool of slots.
+func NewPresized[T any](size int) *List[T] {
+	l := &List[T]{
+		pool: node.NewIndexPoolPresized[T](size + 1),
+	}
+	l.lazyInit()
+
+	return l
+}
+
+// Len returns the number of elements of list.
+func (l *List[T]) Len() int {
+	return l.len
+}
+
+// Iter returns a list iterator with forward direction.
+func (l *List[T]) Iter() *Iter[T] {
+	return &Iter[T]{
+		dir:  iter.ForwardDir,
+		pool: l.pool,
+		c:    l.root,
+		stop: l.root,
+	}
+}
+
+// RIter returns a list iterator with reverse direction.
+func (l *List[T]) RIter() *Iter[T] {
+	return &Iter[T]{
+		dir:  iter.ReverseDir,
+		pool: l.pool,
+		c:    l.root,
+		stop: l.root,
+	}
+}
+
+// Front returns the value of the first element of the list or zero value if the list is empty.
+func (l *List[T]) Front() (v T) {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	if l.len == 0 {
+		return v
+	}
+
+	return l.pool.At(l.pool.At(l.root).Next()).Val()
+}
+
+// Back returns the value of the last element of the list or zero value if the list is empty.
+func (l *List[T]) Back() (v T) {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	if l.len == 0 {
+		return v
+	}
+
+	return l.pool.At(l.pool.At(l.root).Prev()).Val()
+}
+
+// Get returns the value of the i-th element of the list or zero value if the list is empty or len < i.
+// The complexity is O(n).
+func (l *List[T]) Get(i int) (v T) {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	n := l.get(i)
+	if n == node.Nil {
+		return v
+	}
+
+	return l.pool.At(n).Val()
+}
+
+// PushFront inserts a new value at the front of the list.
+func (l *List[T]) PushFront(v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.lazyInit()
+	l.insert(v, l.root)
+}
+
+// PushBack inserts a new value at the back of the list.
+func (l *List[T]) PushBack(v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.lazyInit()
+	l.insert(v, l.pool.At(l.root).Prev())
+}
+
+// PushAfter inserts a new value after the i-th element of the list.
+func (l *List[T]) PushAfter(i int, v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	n := l.get(i)
+	if n == node.Nil {
+		return
+	}
+
+	l.insert(v, n)
+}
+
+// PushBefore inserts a new value before the i-th element of the list.
+func (l *List[T]) PushBefore(i int, v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	n := l.get(i)
+	if n == node.Nil {
+		return
+	}
+
+	l.insert(v, l.pool.At(n).Prev())
+}
+
+// PopFront returns and removes the first element of the list if the list is not empty.
+func (l *List[T]) PopFront() (v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.len == 0 {
+		return v
+	}
+
+	return l.remove(l.pool.At(l.root).Next())
+}
+
+// PopBack returns and removes the last element of the list if the list is not empty.
+func (l *List[T]) PopBack() (v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.len == 0 {
+		return v
+	}
+
+	return l.remove(l.pool.At(l.root).Prev())
+}
+
+// Remove removes the i-th element of the list if the i is less than len.
+func (l *List[T]) Remove(i int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	n := l.get(i)
+	if n == node.Nil {
+		return
+	}
+
+	l.remove(n)
+}
+
+// Set replaces the value of the i-th element of the list if the i is less than len.
+// The complexity is O(n).
+func (l *List[T]) Set(i int, v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if n := l.get(i); n != node.Nil {
+		l.pool.At(n).SetVal(v)
+	}
+}
+
+// Reverse reverses the order of the elements of the list in place.
+// The complexity is O(n).
+func (l *List[T]) Reverse() {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.len < 2 {
+		return
+	}
+
+	n := l.root
+	for {
+		s := l.pool.At(n)
+		next := s.Next()
+		s.SetNext(s.Prev())
+		s.SetPrev(next)
+
+		n = next
+		if n == l.root {
+			break
+		}
+	}
+}
+
+// Rotate rotates the list by n positions.
+// A positive n moves the last n elements to the front of the list,
+// a negative n moves the first -n elements to the back of the list.
+// The complexity is O(min(n, len-n)).
+func (l *List[T]) Rotate(n int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.len < 2 {
+		return
+	}
+
+	n %= l.len
+	if n == 0 {
+		return
+	}
+
+	if n < 0 {
+		n += l.len
+	}
+
+	front := l.get(l.len - n)
+	l.pool.Remove(l.root)
+	l.pool.Insert(l.pool.At(front).Prev(), l.root)
+}
+
+// Swap swaps the i-th and the j-th elements of the list
+// if both indexes are less than len.
+// The complexity is O(n).
+func (l *List[T]) Swap(i, j int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	a, b := l.get(i), l.get(j)
+	if a == node.Nil || b == node.Nil {
+		return
+	}
+
+	as, bs := l.pool.At(a), l.pool.At(b)
+	v := as.Val()
+	as.SetVal(bs.Val())
+	bs.SetVal(v)
+}
+
+// Move moves the element at index from so that it ends up at index to
+// if both indexes are less than len.
+// The complexity is O(n).
+func (l *List[T]) Move(from, to int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if from == to {
+		return
+	}
+
+	n, at := l.get(from), l.get(to)
+	if n == node.Nil || at == node.Nil {
+		return
+	}
+
+	if from > to {
+		at = l.pool.At(at).Prev()
+	}
+
+	l.pool.Remove(n)
+	l.pool.Insert(at, n)
+}
+
+// lazyInit lazily initializes a zero List value.
+func (l *List[T]) lazyInit() {
+	if l.pool == nil {
+		l.pool = node.NewIndexPool[T]()
+	}
+
+	if l.root == node.Nil {
+		l.root = l.pool.Pop()
+		l.pool.At(l.root).SetNext(l.root)
+		l.pool.At(l.root).SetPrev(l.root)
+	}
+}
+
+// insert inserts slot with value v after at, increments len.
+func (l *List[T]) insert(v T, at uint32) {
+	n := l.pool.Pop()
+	l.pool.At(n).SetVal(v)
+	l.pool.Insert(at, n)
+	l.len++
+}
+
+// remove removes n from list, decrements len and returns its value.
+func (l *List[T]) remove(n uint32) T {
+	s := l.pool.At(n)
+	v := s.Val()
+
+	l.pool.Remove(n)
+	l.pool.Push(n)
+	l.len--
+
+	return v
+}
+
+// get returns the index of the i-th slot or Nil if the index is less than zero or greater than the len of the list.
+func (l *List[T]) get(i int) uint32 {
+	if l.len <= i || i < 0 {
+		return node.Nil
+	}
+
+	if i < l.len/2 {
+		n := l.pool.At(l.root).Next()
+		for ; i > 0; i-- {
+			n = l.pool.At(n).Next()
+		}
+
+		return n
+	}
+
+	n := l.pool.At(l.root).Prev()
+	for i = l.len - 1 - i; i > 0; i-- {
+		n = l.pool.At(n).Prev()
+	}
+
+	return n
+}
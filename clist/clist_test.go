@@ -0,0 +1,311 @@
+package clist
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/glebziz/containers/internal/node"
+)
+
+// fill returns a list of the values from 0 to n-1.
+func fill(n int) *List[int] {
+	l := NewPresized[int](n)
+	for i := 0; i < n; i++ {
+		l.PushBack(i)
+	}
+
+	return l
+}
+
+// vals returns the values of the list in order.
+func vals(l *List[int]) []int {
+	res := make([]int, 0, l.Len())
+	for it := l.Iter(); it.Next(); {
+		res = append(res, it.Val())
+	}
+
+	return res
+}
+
+// check checks that the links of the ring of the list are symmetric and the ring has len slots.
+func check(t *testing.T, l *List[int]) {
+	t.Helper()
+
+	count := 0
+	for n := l.pool.At(l.root).Next(); n != l.root; n = l.pool.At(n).Next() {
+		require.NotEqual(t, node.Nil, n)
+		require.Equal(t, n, l.pool.At(l.pool.At(n).Next()).Prev())
+		require.Equal(t, n, l.pool.At(l.pool.At(n).Prev()).Next())
+
+		count++
+		require.LessOrEqual(t, count, l.len)
+	}
+
+	require.Equal(t, l.len, count)
+}
+
+func TestList_Wraparound(t *testing.T) {
+	const (
+		N      = 100
+		Rounds = 100 * N
+	)
+
+	for _, tc := range []struct {
+		name    string
+		change  func(l *List[int])
+		expVals []int
+	}{
+		{
+			name: "pop front push back",
+			change: func(l *List[int]) {
+				for i := 0; i < Rounds+3; i++ {
+					l.PushBack(l.PopFront())
+				}
+			},
+			expVals: append(vals(fill(N))[3:], 0, 1, 2),
+		},
+		{
+			name: "pop back push front",
+			change: func(l *List[int]) {
+				for i := 0; i < Rounds+3; i++ {
+					l.PushFront(l.PopBack())
+				}
+			},
+			expVals: append([]int{N - 3, N - 2, N - 1}, vals(fill(N))[:N-3]...),
+		},
+		{
+			name: "sliding window",
+			change: func(l *List[int]) {
+				for i := N; i < Rounds; i++ {
+					l.PushBack(i)
+					l.PopFront()
+				}
+			},
+			expVals: vals(fill(Rounds))[Rounds-N:],
+		},
+		{
+			name: "drain and refill",
+			change: func(l *List[int]) {
+				for r := 0; r < N; r++ {
+					for l.Len() > 0 {
+						if r%2 == 0 {
+							l.PopFront()
+						} else {
+							l.PopBack()
+						}
+					}
+
+					for i := 0; i < N; i++ {
+						l.PushBack(i)
+					}
+				}
+			},
+			expVals: vals(fill(N)),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := fill(N)
+			root, capacity := l.root, l.pool.Cap()
+
+			tc.change(l)
+
+			require.Equal(t, tc.expVals, vals(l))
+			require.Equal(t, root, l.root)
+			require.Equal(t, capacity, l.pool.Cap())
+			check(t, l)
+
+			for i := 0; i < N; i++ {
+				require.Equal(t, tc.expVals[i], l.Get(i))
+			}
+
+			i := N
+			for it := l.RIter(); it.Next(); {
+				i--
+				require.Equal(t, tc.expVals[i], it.Val())
+			}
+
+			require.Equal(t, 0, i)
+		})
+	}
+}
+
+func TestList_Ops(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		change  func(l *List[int])
+		expVals []int
+	}{
+		{
+			name: "set",
+			change: func(l *List[int]) {
+				l.Set(2, 10)
+				l.Set(5, 10)
+			},
+			expVals: []int{0, 1, 10, 3, 4},
+		},
+		{
+			name: "reverse",
+			change: func(l *List[int]) {
+				l.Reverse()
+			},
+			expVals: []int{4, 3, 2, 1, 0},
+		},
+		{
+			name: "rotate",
+			change: func(l *List[int]) {
+				l.Rotate(2)
+			},
+			expVals: []int{3, 4, 0, 1, 2},
+		},
+		{
+			name: "rotate negative over len",
+			change: func(l *List[int]) {
+				l.Rotate(-6)
+			},
+			expVals: []int{1, 2, 3, 4, 0},
+		},
+		{
+			name: "swap",
+			change: func(l *List[int]) {
+				l.Swap(4, 0)
+				l.Swap(0, 5)
+			},
+			expVals: []int{4, 1, 2, 3, 0},
+		},
+		{
+			name: "move forward",
+			change: func(l *List[int]) {
+				l.Move(0, 4)
+			},
+			expVals: []int{1, 2, 3, 4, 0},
+		},
+		{
+			name: "move backward",
+			change: func(l *List[int]) {
+				l.Move(3, 1)
+				l.Move(-1, 1)
+			},
+			expVals: []int{0, 3, 1, 2, 4},
+		},
+		{
+			name: "remove func",
+			change: func(l *List[int]) {
+				l.RemoveFunc(func(v int) bool {
+					return v%2 == 0
+				})
+			},
+			expVals: []int{1, 3},
+		},
+		{
+			name: "remove first func",
+			change: func(l *List[int]) {
+				l.RemoveFirstFunc(func(v int) bool {
+					return v > 1
+				})
+			},
+			expVals: []int{0, 1, 3, 4},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := fill(5)
+			tc.change(l)
+
+			require.Equal(t, tc.expVals, vals(l))
+			require.Equal(t, len(tc.expVals), l.Len())
+			check(t, l)
+		})
+	}
+}
+
+func TestList_Search(t *testing.T) {
+	t.Parallel()
+
+	l := fill(6)
+	l.Set(4, 1)
+
+	require.Equal(t, 1, Index(l, 1))
+	require.Equal(t, 4, l.LastIndexFunc(func(v int) bool { return v == 1 }))
+	require.Equal(t, -1, Index(l, 10))
+	require.True(t, Contains(l, 5))
+	require.False(t, l.ContainsFunc(func(v int) bool { return v < 0 }))
+	require.Equal(t, 2, RemoveValue(l, 1))
+	require.Equal(t, []int{0, 2, 3, 5}, vals(l))
+}
+
+func TestList_ZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var l List[int]
+	require.Equal(t, 0, l.Get(0))
+	require.Equal(t, 0, l.Front())
+	require.Equal(t, 0, l.Back())
+	require.Equal(t, 0, l.PopFront())
+	require.Equal(t, 0, l.PopBack())
+
+	l.PushAfter(0, 1)
+	l.PushBefore(0, 1)
+	l.Remove(0)
+	l.Rotate(1)
+	l.Reverse()
+	require.Equal(t, -1, Index(&l, 0))
+	require.Equal(t, 0, RemoveValue(&l, 0))
+	require.Equal(t, 0, l.Len())
+
+	l.PushBack(2)
+	l.PushFront(1)
+	require.Equal(t, []int{1, 2}, vals(&l))
+	require.Equal(t, 2, l.Back())
+}
+
+func TestList_Random(t *testing.T) {
+	const (
+		N = 10000
+	)
+
+	var (
+		r     = rand.New(rand.NewSource(1))
+		l     = New[int]()
+		model []int
+	)
+
+	for i := 0; i < N; i++ {
+		switch op := r.Intn(5); {
+		case op < 2 || len(model) == 0:
+			j := r.Intn(len(model) + 1)
+			if j == len(model) {
+				l.PushBack(i)
+			} else {
+				l.PushBefore(j, i)
+			}
+
+			model = append(model[:j], append([]int{i}, model[j:]...)...)
+		case op == 2:
+			j := r.Intn(len(model))
+			l.Remove(j)
+
+			model = append(model[:j], model[j+1:]...)
+		case op == 3:
+			from, to := r.Intn(len(model)), r.Intn(len(model))
+			l.Move(from, to)
+
+			v := model[from]
+			model = append(model[:from], model[from+1:]...)
+			model = append(model[:to], append([]int{v}, model[to:]...)...)
+		default:
+			j := r.Intn(len(model))
+			require.Equal(t, model[j], l.Get(j))
+		}
+	}
+
+	require.Equal(t, len(model), l.Len())
+	require.Equal(t, model, vals(l))
+	check(t, l)
+}
@@ -0,0 +1,21 @@
+package clist_test
+
+import (
+	"fmt"
+
+	"github.com/glebziz/containers/clist"
+)
+
+func ExampleNew() {
+	l := clist.New[string]()
+
+	l.PushBack("World")
+	l.PushFront("Hello")
+	l.PushAfter(1, "!")
+
+	for it := l.Iter(); it.Next(); {
+		fmt.Print(it.Val(), " ")
+	}
+
+	// Output: Hello World !
+}
@@ -0,0 +1,42 @@
+package clist
+
+import (
+	"github.com/glebziz/containers/internal/iter"
+	"github.com/glebziz/containers/internal/node"
+)
+
+// Iter is an iterator over the values of the compact list.
+type Iter[T any] struct {
+	dir  iter.Direction
+	pool *node.IndexPool[T]
+	c    uint32
+	stop uint32
+}
+
+// Next selects the next slot and returns true if it exists.
+// Otherwise, it returns false.
+func (i *Iter[T]) Next() bool {
+	if i.pool == nil || i.stop == node.Nil {
+		return false
+	}
+
+	var next uint32
+	switch i.dir {
+	case iter.ForwardDir:
+		next = i.pool.At(i.c).Next()
+	case iter.ReverseDir:
+		next = i.pool.At(i.c).Prev()
+	}
+
+	if next == i.stop {
+		return false
+	}
+
+	i.c = next
+	return true
+}
+
+// Val returns the value of the current slot.
+func (i *Iter[T]) Val() T {
+	return i.pool.At(i.c).Val()
+}
@@ -0,0 +1,119 @@
+package clist
+
+import (
+	"github.com/glebziz/containers/internal/node"
+)
+
+// IndexFunc returns the index of the first element satisfying pred or -1 if none do.
+// The complexity is O(n).
+func (l *List[T]) IndexFunc(pred func(v T) bool) int {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	i := 0
+	for it := l.Iter(); it.Next(); i++ {
+		if pred(it.Val()) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// LastIndexFunc returns the index of the last element satisfying pred or -1 if none do.
+// The complexity is O(n).
+func (l *List[T]) LastIndexFunc(pred func(v T) bool) int {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	i := l.len - 1
+	for it := l.RIter(); it.Next(); i-- {
+		if pred(it.Val()) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// ContainsFunc reports whether at least one element of the list satisfies pred.
+// The complexity is O(n).
+func (l *List[T]) ContainsFunc(pred func(v T) bool) bool {
+	return l.IndexFunc(pred) >= 0
+}
+
+// RemoveFunc removes all elements satisfying pred and returns the number of removed elements.
+// The complexity is O(n).
+func (l *List[T]) RemoveFunc(pred func(v T) bool) int {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.len == 0 {
+		return 0
+	}
+
+	removed := 0
+	for n := l.pool.At(l.root).Next(); n != l.root; {
+		next := l.pool.At(n).Next()
+		if pred(l.pool.At(n).Val()) {
+			l.remove(n)
+			removed++
+		}
+
+		n = next
+	}
+
+	return removed
+}
+
+// RemoveFirstFunc removes the first element satisfying pred and reports whether it was removed.
+// The complexity is O(n).
+func (l *List[T]) RemoveFirstFunc(pred func(v T) bool) bool {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	n := l.find(pred)
+	if n == node.Nil {
+		return false
+	}
+
+	l.remove(n)
+	return true
+}
+
+// find returns the index of the first slot satisfying pred or Nil if none do.
+func (l *List[T]) find(pred func(v T) bool) uint32 {
+	if l.len == 0 {
+		return node.Nil
+	}
+
+	for n := l.pool.At(l.root).Next(); n != l.root; n = l.pool.At(n).Next() {
+		if pred(l.pool.At(n).Val()) {
+			return n
+		}
+	}
+
+	return node.Nil
+}
+
+// Index returns the index of the first occurrence of v in the list or -1 if not present.
+// The complexity is O(n).
+func Index[T comparable](l *List[T], v T) int {
+	return l.IndexFunc(func(e T) bool {
+		return e == v
+	})
+}
+
+// Contains reports whether v is present in the list.
+// The complexity is O(n).
+func Contains[T comparable](l *List[T], v T) bool {
+	return Index(l, v) >= 0
+}
+
+// RemoveValue removes all occurrences of v from the list and returns the number of removed elements.
+// The complexity is O(n).
+func RemoveValue[T comparable](l *List[T], v T) int {
+	return l.RemoveFunc(func(e T) bool {
+		return e == v
+	})
+}
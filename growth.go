@@ -0,0 +1,24 @@
+package containers
+
+// Growth returns the size of the next chunk of a pool of nodes by the current capacity of the pool.
+type Growth func(capacity int) int
+
+// FixedGrowth returns the growth policy allocating chunks of the same size.
+func FixedGrowth(size int) Growth {
+	return func(int) int {
+		return size
+	}
+}
+
+// DoublingGrowth returns the growth policy doubling the capacity of the pool
+// with chunks of at most maxChunk nodes.
+// If maxChunk is less than one, the size of the chunks is not limited.
+func DoublingGrowth(maxChunk int) Growth {
+	return func(capacity int) int {
+		if maxChunk > 0 && capacity > maxChunk {
+			return maxChunk
+		}
+
+		return capacity
+	}
+}
@@ -0,0 +1,48 @@
+package containers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixedGrowth(t *testing.T) {
+	g := FixedGrowth(100)
+
+	require.Equal(t, 100, g(0))
+	require.Equal(t, 100, g(1000))
+}
+
+func TestDoublingGrowth(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		maxChunk int
+		capacity int
+		expSize  int
+	}{
+		{
+			name:     "unlimited",
+			capacity: 1 << 20,
+			expSize:  1 << 20,
+		},
+		{
+			name:     "below max chunk",
+			maxChunk: 1024,
+			capacity: 512,
+			expSize:  512,
+		},
+		{
+			name:     "above max chunk",
+			maxChunk: 1024,
+			capacity: 4096,
+			expSize:  1024,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expSize, DoublingGrowth(tc.maxChunk)(tc.capacity))
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package ilist
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/glebziz/containers/list"
+)
+
+var sizes = []int{1000, 100000}
+
+func BenchmarkList_PushBack(b *testing.B) {
+	b.Run("list", func(b *testing.B) {
+		b.ReportAllocs()
+
+		l := list.NewPresized[int](b.N)
+
+		for i := 0; i < b.N; i++ {
+			l.PushBack(i)
+		}
+	})
+
+	b.Run("indexed list", func(b *testing.B) {
+		b.ReportAllocs()
+
+		l := NewPresized[int](b.N)
+
+		for i := 0; i < b.N; i++ {
+			l.PushBack(i)
+		}
+	})
+}
+
+func BenchmarkList_Get(b *testing.B) {
+	for _, size := range sizes {
+		b.Run(fmt.Sprintf("list %d", size), func(b *testing.B) {
+			b.ReportAllocs()
+
+			l := list.NewPresized[int](size)
+			for i := 0; i < size; i++ {
+				l.PushBack(i)
+			}
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				l.Get(i * 7919 % size)
+			}
+		})
+
+		b.Run(fmt.Sprintf("indexed list %d", size), func(b *testing.B) {
+			b.ReportAllocs()
+
+			l := NewPresized[int](size)
+			for i := 0; i < size; i++ {
+				l.PushBack(i)
+			}
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				l.Get(i * 7919 % size)
+			}
+		})
+	}
+}
+
+func BenchmarkList_InsertRemove(b *testing.B) {
+	for _, size := range sizes {
+		b.Run(fmt.Sprintf("list %d", size), func(b *testing.B) {
+			b.ReportAllocs()
+
+			l := list.NewPresized[int](size + 1)
+			for i := 0; i < size; i++ {
+				l.PushBack(i)
+			}
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				j := i * 7919 % size
+				l.PushAfter(j, i)
+				l.Remove(j)
+			}
+		})
+
+		b.Run(fmt.Sprintf("indexed list %d", size), func(b *testing.B) {
+			b.ReportAllocs()
+
+			l := NewPresized[int](size + 1)
+			for i := 0; i < size; i++ {
+				l.PushBack(i)
+			}
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				j := i * 7919 % size
+				l.PushAfter(j, i)
+				l.Remove(j)
+			}
+		})
+	}
+}
+
+func BenchmarkList_Iter(b *testing.B) {
+	b.Run("list", func(b *testing.B) {
+		b.ReportAllocs()
+
+		l := list.NewPresized[int](b.N)
+		for i := 0; i < b.N; i++ {
+			l.PushBack(i)
+		}
+
+		b.ResetTimer()
+
+		for it := l.Iter(); it.Next(); {
+			_ = it.Val()
+		}
+	})
+
+	b.Run("indexed list", func(b *testing.B) {
+		b.ReportAllocs()
+
+		l := NewPresized[int](b.N)
+		for i := 0; i < b.N; i++ {
+			l.PushBack(i)
+		}
+
+		b.ResetTimer()
+
+		for it := l.Iter(); it.Next(); {
+			_ = it.Val()
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package ilist_test
+
+import (
+	"fmt"
+
+	"github.com/glebziz/containers/ilist"
+)
+
+func ExampleNew() {
+	l := ilist.New[string]()
+
+	l.PushBack("World")
+	l.PushFront("Hello")
+	l.PushAfter(1, "!")
+
+	fmt.Println(l.Get(1))
+
+	for it := l.Iter(); it.Next(); {
+		fmt.Print(it.Val(), " ")
+	}
+
+	// Output:
+	// World
+	// Hello World !
+}
@@ -0,0 +1,401 @@
+// Package ilist implements an indexed list with a pool of nodes.
+//
+// The list is an implicit treap: a randomized balanced binary tree keyed by the position of the element,
+// so the positional operations have O(log n) expected complexity instead of O(n) of a linked list.
+//
+// The list has the element API of list.List: the pushes and pops, Get, Set, Reverse, Rotate, Swap, Move
+// and the search functions. The bounded and sized lists, the hooks and the transactions of list.List
+// are not provided, so the tree operations need no undo log and no events, and Set returns no error.
+//
+// To iterate over a list (where l is a *List):
+//
+//	it := l.Iter()
+//	for it.Next() {
+//		// do something with it.Val()
+//	}
+package ilist
+
+import (
+	"sync"
+
+	"github.com/glebziz/containers/internal/iter"
+	"github.com/glebziz/containers/internal/node"
+)
+
+// seed is the initial state of the priority generator of a zero List value.
+const seed = 0x9e3779b97f4a7c15
+
+// List represents an indexed list.
+// The zero value for List is an empty list ready to use.
+type List[T any] struct {
+	root *node.Node[item[T]]
+	pool *node.Pool[item[T]]
+	rand uint64
+	len  int
+
+	m sync.RWMutex
+}
+
+// item is an element of the list with its treap priority and the size of its subtree.
+// The previous node of a tree node is its left child and the next node is its right child.
+type item[T any] struct {
+	val  T
+	prio uint64
+	size int
+}
+
+// Reset resets the value of the item if the pointer to the value implements node.Resetter.
+// It is called by the pool when the node of the item is recycled.
+func (it *item[T]) Reset() {
+	if r, ok := any(&it.val).(node.Resetter); ok {
+		r.Reset()
+	}
+}
+
+// New returns an initialized list.
+func New[T any]() *List[T] {
+	return &List[T]{
+		pool: node.NewPool[item[T]](),
+		rand: seed,
+	}
+}
+
+// NewPresized returns an initialized list with an allocated pool of nodes.
+func NewPresized[T any](size int) *List[T] {
+	return &List[T]{
+		pool: node.NewPoolPresized[item[T]](size),
+		rand: seed,
+	}
+}
+
+// Len returns the number of elements of list.
+func (l *List[T]) Len() int {
+	return l.len
+}
+
+// Iter returns a list iterator with forward direction.
+func (l *List[T]) Iter() *Iter[T] {
+	return newIter(l.root, iter.ForwardDir)
+}
+
+// RIter returns a list iterator with reverse direction.
+func (l *List[T]) RIter() *Iter[T] {
+	return newIter(l.root, iter.ReverseDir)
+}
+
+// Front returns the value of the first element of the list or zero value if the list is empty.
+// The complexity is O(log n).
+func (l *List[T]) Front() T {
+	return l.Get(0)
+}
+
+// Back returns the value of the last element of the list or zero value if the list is empty.
+// The complexity is O(log n).
+func (l *List[T]) Back() T {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	return l.get(l.len - 1).Val().val
+}
+
+// Get returns the value of the i-th element of the list or zero value if the list is empty or len < i.
+// The complexity is O(log n).
+func (l *List[T]) Get(i int) T {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	return l.get(i).Val().val
+}
+
+// PushFront inserts a new value at the front of the list.
+// The complexity is O(log n).
+func (l *List[T]) PushFront(v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.insert(0, v)
+}
+
+// PushBack inserts a new value at the back of the list.
+// The complexity is O(log n).
+func (l *List[T]) PushBack(v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.insert(l.len, v)
+}
+
+// PushAfter inserts a new value after the i-th element of the list.
+// The complexity is O(log n).
+func (l *List[T]) PushAfter(i int, v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if i < 0 || i >= l.len {
+		return
+	}
+
+	l.insert(i+1, v)
+}
+
+// PushBefore inserts a new value before the i-th element of the list.
+// The complexity is O(log n).
+func (l *List[T]) PushBefore(i int, v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if i < 0 || i >= l.len {
+		return
+	}
+
+	l.insert(i, v)
+}
+
+// PopFront returns and removes the first element of the list if the list is not empty.
+// The complexity is O(log n).
+func (l *List[T]) PopFront() T {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	return l.remove(0)
+}
+
+// PopBack returns and removes the last element of the list if the list is not empty.
+// The complexity is O(log n).
+func (l *List[T]) PopBack() T {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	return l.remove(l.len - 1)
+}
+
+// Remove removes the i-th element of the list if the i is less than len.
+// The complexity is O(log n).
+func (l *List[T]) Remove(i int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.remove(i)
+}
+
+// Set replaces the value of the i-th element of the list if the i is less than len.
+// The complexity is O(log n).
+func (l *List[T]) Set(i int, v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if n := l.get(i); n != nil {
+		n.Ref().val = v
+	}
+}
+
+// Reverse reverses the order of the elements of the list in place.
+// The complexity is O(n).
+func (l *List[T]) Reverse() {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	mirror(l.root)
+}
+
+// Rotate rotates the list by n positions.
+// A positive n moves the last n elements to the front of the list,
+// a negative n moves the first -n elements to the back of the list.
+// The complexity is O(log n).
+func (l *List[T]) Rotate(n int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.len < 2 {
+		return
+	}
+
+	n %= l.len
+	if n < 0 {
+		n += l.len
+	}
+
+	a, b := split(l.root, l.len-n)
+	l.root = merge(b, a)
+}
+
+// Swap swaps the i-th and the j-th elements of the list
+// if both indexes are less than len.
+// The complexity is O(log n).
+func (l *List[T]) Swap(i, j int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	a, b := l.get(i), l.get(j)
+	if a == nil || b == nil {
+		return
+	}
+
+	av, bv := a.Ref(), b.Ref()
+	av.val, bv.val = bv.val, av.val
+}
+
+// Move moves the element at index from so that it ends up at index to
+// if both indexes are less than len.
+// The complexity is O(log n).
+func (l *List[T]) Move(from, to int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if from == to || from < 0 || from >= l.len || to < 0 || to >= l.len {
+		return
+	}
+
+	a, b := split(l.root, from)
+	n, c := split(b, 1)
+	a, b = split(merge(a, c), to)
+	l.root = merge(merge(a, n), b)
+}
+
+// insert inserts a new node with value v at the position i, increments len.
+func (l *List[T]) insert(i int, v T) {
+	if l.pool == nil {
+		l.pool = node.NewPool[item[T]]()
+	}
+
+	n := l.pool.Pop()
+	n.SetNext(nil)
+	n.SetPrev(nil)
+	n.SetVal(item[T]{
+		val:  v,
+		prio: l.next(),
+		size: 1,
+	})
+
+	a, b := split(l.root, i)
+	l.root = merge(merge(a, n), b)
+	l.len++
+}
+
+// remove removes the i-th node from list, decrements len and returns its value.
+// If the index is out of range, zero value is returned.
+func (l *List[T]) remove(i int) (v T) {
+	if i < 0 || i >= l.len {
+		return v
+	}
+
+	a, b := split(l.root, i)
+	n, c := split(b, 1)
+	l.root = merge(a, c)
+	l.len--
+
+	v = n.Val().val
+	l.pool.Push(n)
+
+	return v
+}
+
+// get returns the i-th node or nil if the index is less than zero or greater than the len of the list.
+func (l *List[T]) get(i int) *node.Node[item[T]] {
+	if i < 0 || i >= l.len {
+		return nil
+	}
+
+	n := l.root
+	for n != nil {
+		left := size(n.Prev())
+		switch {
+		case i < left:
+			n = n.Prev()
+		case i > left:
+			i -= left + 1
+			n = n.Next()
+		default:
+			return n
+		}
+	}
+
+	return nil
+}
+
+// next returns the next pseudo-random priority using the xorshift generator.
+func (l *List[T]) next() uint64 {
+	if l.rand == 0 {
+		l.rand = seed
+	}
+
+	l.rand ^= l.rand << 13
+	l.rand ^= l.rand >> 7
+	l.rand ^= l.rand << 17
+
+	return l.rand
+}
+
+// split splits the tree into the tree of the first k elements and the tree of the rest.
+func split[T any](n *node.Node[item[T]], k int) (*node.Node[item[T]], *node.Node[item[T]]) {
+	if n == nil {
+		return nil, nil
+	}
+
+	left := size(n.Prev())
+	if k <= left {
+		a, b := split(n.Prev(), k)
+		n.SetPrev(b)
+		update(n)
+
+		return a, n
+	}
+
+	a, b := split(n.Next(), k-left-1)
+	n.SetNext(a)
+	update(n)
+
+	return n, b
+}
+
+// merge merges two trees where all elements of a precede the elements of b.
+func merge[T any](a, b *node.Node[item[T]]) *node.Node[item[T]] {
+	if a == nil {
+		return b
+	}
+
+	if b == nil {
+		return a
+	}
+
+	if a.Val().prio > b.Val().prio {
+		a.SetNext(merge(a.Next(), b))
+		update(a)
+
+		return a
+	}
+
+	b.SetPrev(merge(a, b.Prev()))
+	update(b)
+
+	return b
+}
+
+// mirror swaps the children of every node of the tree with the root n,
+// so the order of the elements is reversed while the sizes and the priorities stay valid.
+func mirror[T any](n *node.Node[item[T]]) {
+	if n == nil {
+		return
+	}
+
+	left, right := n.Prev(), n.Next()
+	n.SetPrev(right)
+	n.SetNext(left)
+
+	mirror(left)
+	mirror(right)
+}
+
+// update recalculates the size of the subtree of n.
+func update[T any](n *node.Node[item[T]]) {
+	n.Ref().size = size(n.Prev()) + size(n.Next()) + 1
+}
+
+// size returns the size of the subtree of n or zero if n is nil.
+func size[T any](n *node.Node[item[T]]) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.Ref().size
+}
@@ -0,0 +1,376 @@
+package ilist
+
+import (
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/glebziz/containers/internal/node"
+)
+
+// fill returns a list of the values from 0 to n-1.
+func fill(n int) *List[int] {
+	l := NewPresized[int](n)
+	for i := 0; i < n; i++ {
+		l.PushBack(i)
+	}
+
+	return l
+}
+
+// vals returns the values of the list in order.
+func vals(l *List[int]) []int {
+	res := make([]int, 0, l.Len())
+	for it := l.Iter(); it.Next(); {
+		res = append(res, it.Val())
+	}
+
+	return res
+}
+
+// check checks the sizes and the heap order of the priorities of the treap and returns its height.
+func check(t *testing.T, l *List[int]) int {
+	t.Helper()
+
+	var walk func(n *node.Node[item[int]]) (int, int)
+	walk = func(n *node.Node[item[int]]) (int, int) {
+		if n == nil {
+			return 0, 0
+		}
+
+		for _, c := range []*node.Node[item[int]]{n.Prev(), n.Next()} {
+			if c != nil {
+				require.LessOrEqual(t, c.Val().prio, n.Val().prio)
+			}
+		}
+
+		ls, lh := walk(n.Prev())
+		rs, rh := walk(n.Next())
+		require.Equal(t, ls+rs+1, n.Val().size)
+
+		return n.Val().size, max(lh, rh) + 1
+	}
+
+	s, h := walk(l.root)
+	require.Equal(t, l.len, s)
+
+	return h
+}
+
+func TestList_Balance(t *testing.T) {
+	const (
+		N = 1 << 14
+	)
+
+	for _, tc := range []struct {
+		name   string
+		change func(l *List[int])
+		expLen int
+	}{
+		{
+			name: "push front",
+			change: func(l *List[int]) {
+				for i := 0; i < N; i++ {
+					l.PushFront(i)
+				}
+			},
+			expLen: N,
+		},
+		{
+			name: "push back",
+			change: func(l *List[int]) {
+				for i := 0; i < N; i++ {
+					l.PushBack(i)
+				}
+			},
+			expLen: N,
+		},
+		{
+			name: "push after last",
+			change: func(l *List[int]) {
+				l.PushBack(0)
+				for i := 1; i < N; i++ {
+					l.PushAfter(i-1, i)
+				}
+			},
+			expLen: N,
+		},
+		{
+			name: "push before first",
+			change: func(l *List[int]) {
+				l.PushBack(0)
+				for i := 1; i < N; i++ {
+					l.PushBefore(0, i)
+				}
+			},
+			expLen: N,
+		},
+		{
+			name: "pop front and push back",
+			change: func(l *List[int]) {
+				for i := 0; i < N; i++ {
+					l.PushBack(i)
+				}
+
+				for i := 0; i < 4*N; i++ {
+					l.PushBack(l.PopFront())
+				}
+			},
+			expLen: N,
+		},
+		{
+			name: "remove last",
+			change: func(l *List[int]) {
+				for i := 0; i < 2*N; i++ {
+					l.PushBack(i)
+				}
+
+				for i := 0; i < N; i++ {
+					l.Remove(l.Len() - 1)
+				}
+			},
+			expLen: N,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := New[int]()
+			tc.change(l)
+
+			require.Equal(t, tc.expLen, l.Len())
+
+			h := check(t, l)
+			require.LessOrEqual(t, h, 3*bits.Len(N), "height of %d elements", N)
+		})
+	}
+}
+
+func TestList_Ops(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		change  func(l *List[int])
+		expVals []int
+	}{
+		{
+			name: "set",
+			change: func(l *List[int]) {
+				l.Set(2, 10)
+			},
+			expVals: []int{0, 1, 10, 3, 4},
+		},
+		{
+			name: "set out of range",
+			change: func(l *List[int]) {
+				l.Set(5, 10)
+			},
+			expVals: []int{0, 1, 2, 3, 4},
+		},
+		{
+			name: "reverse",
+			change: func(l *List[int]) {
+				l.Reverse()
+			},
+			expVals: []int{4, 3, 2, 1, 0},
+		},
+		{
+			name: "rotate",
+			change: func(l *List[int]) {
+				l.Rotate(2)
+			},
+			expVals: []int{3, 4, 0, 1, 2},
+		},
+		{
+			name: "rotate negative over len",
+			change: func(l *List[int]) {
+				l.Rotate(-6)
+			},
+			expVals: []int{1, 2, 3, 4, 0},
+		},
+		{
+			name: "swap",
+			change: func(l *List[int]) {
+				l.Swap(4, 0)
+			},
+			expVals: []int{4, 1, 2, 3, 0},
+		},
+		{
+			name: "swap out of range",
+			change: func(l *List[int]) {
+				l.Swap(0, 5)
+			},
+			expVals: []int{0, 1, 2, 3, 4},
+		},
+		{
+			name: "move forward",
+			change: func(l *List[int]) {
+				l.Move(0, 4)
+			},
+			expVals: []int{1, 2, 3, 4, 0},
+		},
+		{
+			name: "move backward",
+			change: func(l *List[int]) {
+				l.Move(3, 1)
+			},
+			expVals: []int{0, 3, 1, 2, 4},
+		},
+		{
+			name: "move out of range",
+			change: func(l *List[int]) {
+				l.Move(-1, 1)
+			},
+			expVals: []int{0, 1, 2, 3, 4},
+		},
+		{
+			name: "remove func",
+			change: func(l *List[int]) {
+				l.RemoveFunc(func(v int) bool {
+					return v%2 == 0
+				})
+			},
+			expVals: []int{1, 3},
+		},
+		{
+			name: "remove first func",
+			change: func(l *List[int]) {
+				l.RemoveFirstFunc(func(v int) bool {
+					return v > 1
+				})
+			},
+			expVals: []int{0, 1, 3, 4},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := fill(5)
+			tc.change(l)
+
+			require.Equal(t, tc.expVals, vals(l))
+			require.Equal(t, len(tc.expVals), l.Len())
+			check(t, l)
+
+			for i, v := range tc.expVals {
+				require.Equal(t, v, l.Get(i))
+			}
+		})
+	}
+}
+
+func TestList_Search(t *testing.T) {
+	t.Parallel()
+
+	l := fill(6)
+	l.Set(4, 1)
+
+	require.Equal(t, 1, Index(l, 1))
+	require.Equal(t, 4, l.LastIndexFunc(func(v int) bool { return v == 1 }))
+	require.Equal(t, -1, Index(l, 10))
+	require.True(t, Contains(l, 5))
+	require.False(t, l.ContainsFunc(func(v int) bool { return v < 0 }))
+	require.Equal(t, 2, RemoveValue(l, 1))
+	require.Equal(t, []int{0, 2, 3, 5}, vals(l))
+}
+
+func TestList_ZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var l List[int]
+	require.Equal(t, 0, l.Get(0))
+	require.Equal(t, 0, l.Front())
+	require.Equal(t, 0, l.Back())
+	require.Equal(t, 0, l.PopFront())
+	require.Equal(t, 0, l.PopBack())
+
+	l.PushAfter(0, 1)
+	l.PushBefore(0, 1)
+	l.Rotate(1)
+	l.Reverse()
+	require.Equal(t, 0, l.Len())
+
+	l.PushBack(2)
+	l.PushFront(1)
+	require.Equal(t, []int{1, 2}, vals(&l))
+	require.Equal(t, 2, l.Back())
+}
+
+func TestList_Random(t *testing.T) {
+	const (
+		N = 10000
+	)
+
+	var (
+		r     = rand.New(rand.NewSource(1))
+		l     = New[int]()
+		model []int
+	)
+
+	for i := 0; i < N; i++ {
+		switch op := r.Intn(5); {
+		case op < 2 || len(model) == 0:
+			j := r.Intn(len(model) + 1)
+			if j == len(model) {
+				l.PushBack(i)
+			} else {
+				l.PushBefore(j, i)
+			}
+
+			model = append(model[:j], append([]int{i}, model[j:]...)...)
+		case op == 2:
+			j := r.Intn(len(model))
+			l.Remove(j)
+
+			model = append(model[:j], model[j+1:]...)
+		case op == 3:
+			from, to := r.Intn(len(model)), r.Intn(len(model))
+			l.Move(from, to)
+
+			v := model[from]
+			model = append(model[:from], model[from+1:]...)
+			model = append(model[:to], append([]int{v}, model[to:]...)...)
+		default:
+			j := r.Intn(len(model))
+			require.Equal(t, model[j], l.Get(j))
+		}
+	}
+
+	require.Equal(t, len(model), l.Len())
+	require.Equal(t, model, vals(l))
+	check(t, l)
+}
+
+type resetCounter struct {
+	resets *int
+}
+
+func (r *resetCounter) Reset() {
+	*r.resets++
+}
+
+func TestList_Reset(t *testing.T) {
+	t.Parallel()
+
+	resets := 0
+
+	l := New[resetCounter]()
+	for i := 0; i < 5; i++ {
+		l.PushBack(resetCounter{resets: &resets})
+	}
+
+	l.PopFront()
+	l.Remove(1)
+	require.Equal(t, 2, resets)
+
+	require.Equal(t, 3, l.RemoveFunc(func(resetCounter) bool { return true }))
+	require.Equal(t, 5, resets)
+
+	pl := New[*resetCounter]()
+	pl.PushBack(&resetCounter{resets: &resets})
+	pl.PopBack()
+	require.Equal(t, 5, resets)
+}
@@ -0,0 +1,64 @@
+package ilist
+
+import (
+	"github.com/glebziz/containers/internal/iter"
+	"github.com/glebziz/containers/internal/node"
+)
+
+// Iter is an in-order iterator over the values of the indexed list.
+// It keeps the path from the root to the current node, so it uses O(log n) memory.
+type Iter[T any] struct {
+	dir   iter.Direction
+	stack []*node.Node[item[T]]
+	c     *node.Node[item[T]]
+}
+
+// newIter returns an initialised iterator over the tree with the root n.
+func newIter[T any](n *node.Node[item[T]], dir iter.Direction) *Iter[T] {
+	i := &Iter[T]{
+		dir: dir,
+	}
+	i.descend(n)
+
+	return i
+}
+
+// Next selects the next node and returns true if it exists.
+// Otherwise, it returns false.
+// The amortized complexity is O(1).
+func (i *Iter[T]) Next() bool {
+	if len(i.stack) == 0 {
+		return false
+	}
+
+	i.c = i.stack[len(i.stack)-1]
+	i.stack = i.stack[:len(i.stack)-1]
+
+	switch i.dir {
+	case iter.ForwardDir:
+		i.descend(i.c.Next())
+	case iter.ReverseDir:
+		i.descend(i.c.Prev())
+	}
+
+	return true
+}
+
+// Val returns the value of the current node.
+func (i *Iter[T]) Val() T {
+	return i.c.Val().val
+}
+
+// descend pushes n and its descendants on the path to the first node of the subtree in the iterator direction.
+func (i *Iter[T]) descend(n *node.Node[item[T]]) {
+	for n != nil {
+		i.stack = append(i.stack, n)
+
+		switch i.dir {
+		case iter.ForwardDir:
+			n = n.Prev()
+		case iter.ReverseDir:
+			n = n.Next()
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package ilist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIter(t *testing.T) {
+	const (
+		N = 100
+	)
+
+	l := NewPresized[int](N)
+
+	it := l.Iter()
+	require.False(t, it.Next())
+
+	for i := 0; i < N; i++ {
+		l.PushBack(i)
+	}
+
+	i := 0
+	it = l.Iter()
+
+	for it.Next() {
+		require.Equal(t, i, it.Val())
+		i++
+	}
+
+	require.Equal(t, N, i)
+}
+
+func TestRIter(t *testing.T) {
+	const (
+		N = 100
+	)
+
+	l := NewPresized[int](N)
+
+	it := l.RIter()
+	require.False(t, it.Next())
+
+	for i := 0; i < N; i++ {
+		l.PushBack(i)
+	}
+
+	i := N
+	it = l.RIter()
+
+	for it.Next() {
+		i--
+		require.Equal(t, i, it.Val())
+	}
+
+	require.Equal(t, 0, i)
+}
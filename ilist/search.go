@@ -0,0 +1,121 @@
+package ilist
+
+import (
+	"github.com/glebziz/containers/internal/node"
+)
+
+// IndexFunc returns the index of the first element satisfying pred or -1 if none do.
+// The complexity is O(n).
+func (l *List[T]) IndexFunc(pred func(v T) bool) int {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	return l.index(pred)
+}
+
+// LastIndexFunc returns the index of the last element satisfying pred or -1 if none do.
+// The complexity is O(n).
+func (l *List[T]) LastIndexFunc(pred func(v T) bool) int {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	i := l.len - 1
+	for it := l.RIter(); it.Next(); i-- {
+		if pred(it.Val()) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// ContainsFunc reports whether at least one element of the list satisfies pred.
+// The complexity is O(n).
+func (l *List[T]) ContainsFunc(pred func(v T) bool) bool {
+	return l.IndexFunc(pred) >= 0
+}
+
+// RemoveFunc removes all elements satisfying pred and returns the number of removed elements.
+// The complexity is O(n).
+func (l *List[T]) RemoveFunc(pred func(v T) bool) int {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	root, removed := l.filter(l.root, pred)
+	l.root = root
+	l.len -= removed
+
+	return removed
+}
+
+// RemoveFirstFunc removes the first element satisfying pred and reports whether it was removed.
+// The complexity is O(n).
+func (l *List[T]) RemoveFirstFunc(pred func(v T) bool) bool {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	i := l.index(pred)
+	if i < 0 {
+		return false
+	}
+
+	l.remove(i)
+	return true
+}
+
+// index returns the index of the first element satisfying pred or -1 if none do.
+func (l *List[T]) index(pred func(v T) bool) int {
+	i := 0
+	for it := l.Iter(); it.Next(); i++ {
+		if pred(it.Val()) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// filter removes the nodes satisfying pred from the tree with the root n in order,
+// returns the root of the rest of the tree and the number of removed nodes.
+func (l *List[T]) filter(n *node.Node[item[T]], pred func(v T) bool) (*node.Node[item[T]], int) {
+	if n == nil {
+		return nil, 0
+	}
+
+	left, a := l.filter(n.Prev(), pred)
+	drop := pred(n.Val().val)
+	right, b := l.filter(n.Next(), pred)
+
+	if drop {
+		l.pool.Push(n)
+		return merge(left, right), a + b + 1
+	}
+
+	n.SetPrev(left)
+	n.SetNext(right)
+	update(n)
+
+	return n, a + b
+}
+
+// Index returns the index of the first occurrence of v in the list or -1 if not present.
+// The complexity is O(n).
+func Index[T comparable](l *List[T], v T) int {
+	return l.IndexFunc(func(e T) bool {
+		return e == v
+	})
+}
+
+// Contains reports whether v is present in the list.
+// The complexity is O(n).
+func Contains[T comparable](l *List[T], v T) bool {
+	return Index(l, v) >= 0
+}
+
+// RemoveValue removes all occurrences of v from the list and returns the number of removed elements.
+// The complexity is O(n).
+func RemoveValue[T comparable](l *List[T], v T) int {
+	return l.RemoveFunc(func(e T) bool {
+		return e == v
+	})
+}
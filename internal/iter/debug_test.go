@@ -0,0 +1,32 @@
+//go:build containers_debug
+
+package iter
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/glebziz/containers/internal/node"
+)
+
+func TestIter_ModifiedPanics(t *testing.T) {
+	var (
+		root node.Node[int]
+		n    node.Node[int]
+		mod  atomic.Uint64
+	)
+
+	root.SetNext(&root)
+	root.SetPrev(&root)
+	root.Insert(&n)
+
+	it := NewChecked[int](&root, ForwardDir, &mod)
+	require.True(t, it.Next())
+
+	mod.Add(1)
+	require.PanicsWithValue(t, ErrModified, func() {
+		it.Next()
+	})
+}
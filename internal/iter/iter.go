@@ -1,6 +1,14 @@
 package iter
 
-import "github.com/glebziz/containers/internal/node"
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/glebziz/containers/internal/node"
+)
+
+// ErrModified is reported by an iterator when its container was structurally modified during the iteration.
+var ErrModified = errors.New("container was modified during iteration")
 
 // Direction is the direction of the iterator.
 type Direction bool
@@ -13,10 +21,17 @@ const (
 )
 
 // Iter is an iterator that supports iterating over the values of container types.
+// A checked iterator stops with ErrModified if the modification counter of the container has changed,
+// because the current node may have been removed and recycled by the pool.
+// Both Next and Val check the counter, so Val never returns the value of a recycled node.
 type Iter[T any] struct {
 	dir  Direction
 	c    *node.Node[T]
 	stop *node.Node[T]
+
+	mod *atomic.Uint64
+	seq uint64
+	err error
 }
 
 // New returns an initialised iterator.
@@ -28,9 +43,26 @@ func New[T any](n *node.Node[T], dir Direction) *Iter[T] {
 	}
 }
 
+// NewChecked returns an initialised iterator that checks the modification counter of the container.
+func NewChecked[T any](n *node.Node[T], dir Direction, mod *atomic.Uint64) *Iter[T] {
+	return &Iter[T]{
+		dir:  dir,
+		c:    n,
+		stop: n,
+		mod:  mod,
+		seq:  mod.Load(),
+	}
+}
+
 // Next selects the next node and returns true if it exists.
 // Otherwise, it returns false.
+// If the container was modified after the iterator was created, Next returns false and Err returns ErrModified,
+// or Next panics with ErrModified if the package is built with the containers_debug tag.
 func (i *Iter[T]) Next() bool {
+	if i.stopped() {
+		return false
+	}
+
 	var next *node.Node[T]
 	switch i.dir {
 	case ForwardDir:
@@ -48,6 +80,73 @@ func (i *Iter[T]) Next() bool {
 }
 
 // Val returns the value of the current node.
+// If the container was modified after the iterator was created, Val returns zero value and Err returns ErrModified,
+// or Val panics with ErrModified if the package is built with the containers_debug tag.
 func (i *Iter[T]) Val() T {
+	if i.stopped() {
+		var v T
+		return v
+	}
+
 	return i.c.Val()
 }
+
+// Err returns ErrModified if the iteration was stopped because the container was modified.
+// Otherwise, it returns nil.
+func (i *Iter[T]) Err() error {
+	return i.err
+}
+
+// stopped reports whether the iteration was stopped because the container was modified.
+// The first detected modification sets ErrModified, or panics if the package is built with the containers_debug tag.
+func (i *Iter[T]) stopped() bool {
+	if i.err != nil {
+		return true
+	}
+
+	if i.mod == nil || i.mod.Load() == i.seq {
+		return false
+	}
+
+	i.err = ErrModified
+	if debug {
+		panic(ErrModified)
+	}
+
+	return true
+}
+
+// MapIter is an iterator that supports iterating over the key-value pairs of map container types.
+type MapIter[K, V any] struct {
+	Iter[node.Entry[K, V]]
+}
+
+// NewMap returns an initialised key-value iterator.
+func NewMap[K, V any](n *node.Node[node.Entry[K, V]], dir Direction) *MapIter[K, V] {
+	return &MapIter[K, V]{
+		Iter: Iter[node.Entry[K, V]]{
+			dir:  dir,
+			c:    n,
+			stop: n,
+		},
+	}
+}
+
+// NewCheckedMap returns an initialised key-value iterator that checks the modification counter of the container.
+func NewCheckedMap[K, V any](n *node.Node[node.Entry[K, V]], dir Direction, mod *atomic.Uint64) *MapIter[K, V] {
+	return &MapIter[K, V]{
+		Iter: *NewChecked[node.Entry[K, V]](n, dir, mod),
+	}
+}
+
+// Key returns the key of the current node.
+// After a modification of the container, it behaves like Val.
+func (i *MapIter[K, V]) Key() K {
+	return i.Iter.Val().Key
+}
+
+// Val returns the value of the current node.
+// After a modification of the container, it returns zero value or panics like Iter.Val.
+func (i *MapIter[K, V]) Val() V {
+	return i.Iter.Val().Val
+}
@@ -0,0 +1,199 @@
+package iter
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/glebziz/containers/internal/node"
+)
+
+// ring returns the root of a ring of nodes with the values.
+func ring[T any](vals ...T) *node.Node[T] {
+	root := &node.Node[T]{}
+	root.SetNext(root)
+	root.SetPrev(root)
+
+	for _, v := range vals {
+		n := &node.Node[T]{}
+		n.SetVal(v)
+		root.Prev().Insert(n)
+	}
+
+	return root
+}
+
+// modified asserts that fn stops with ErrModified,
+// which is a panic if the package is built with the containers_debug tag.
+func modified(t *testing.T, fn func()) {
+	t.Helper()
+
+	if debug {
+		require.PanicsWithValue(t, ErrModified, fn)
+		return
+	}
+
+	require.NotPanics(t, fn)
+}
+
+func TestIter(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		it      func(root *node.Node[int], mod *atomic.Uint64) *Iter[int]
+		expVals []int
+	}{
+		{
+			name: "forward",
+			it: func(root *node.Node[int], _ *atomic.Uint64) *Iter[int] {
+				return New(root, ForwardDir)
+			},
+			expVals: []int{1, 2, 3},
+		},
+		{
+			name: "reverse",
+			it: func(root *node.Node[int], _ *atomic.Uint64) *Iter[int] {
+				return New(root, ReverseDir)
+			},
+			expVals: []int{3, 2, 1},
+		},
+		{
+			name: "checked forward",
+			it: func(root *node.Node[int], mod *atomic.Uint64) *Iter[int] {
+				return NewChecked(root, ForwardDir, mod)
+			},
+			expVals: []int{1, 2, 3},
+		},
+		{
+			name: "checked reverse",
+			it: func(root *node.Node[int], mod *atomic.Uint64) *Iter[int] {
+				return NewChecked(root, ReverseDir, mod)
+			},
+			expVals: []int{3, 2, 1},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var mod atomic.Uint64
+			mod.Store(5)
+
+			it := tc.it(ring(1, 2, 3), &mod)
+
+			vals := []int{}
+			for it.Next() {
+				vals = append(vals, it.Val())
+			}
+
+			require.Equal(t, tc.expVals, vals)
+			require.NoError(t, it.Err())
+			require.False(t, it.Next())
+		})
+	}
+}
+
+func TestIter_Modified(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		change func(it *Iter[int], mod *atomic.Uint64)
+	}{
+		{
+			name: "before next",
+			change: func(_ *Iter[int], mod *atomic.Uint64) {
+				mod.Add(1)
+			},
+		},
+		{
+			name: "during iteration",
+			change: func(it *Iter[int], mod *atomic.Uint64) {
+				it.Next()
+				mod.Add(1)
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var mod atomic.Uint64
+
+			it := NewChecked(ring(1, 2, 3), ForwardDir, &mod)
+			tc.change(it, &mod)
+
+			var v int
+			modified(t, func() {
+				v = it.Val()
+			})
+
+			require.Zero(t, v)
+			require.ErrorIs(t, it.Err(), ErrModified)
+			require.False(t, it.Next())
+			require.Zero(t, it.Val())
+		})
+	}
+
+	t.Run("next", func(t *testing.T) {
+		t.Parallel()
+
+		var mod atomic.Uint64
+
+		it := NewChecked(ring(1, 2, 3), ForwardDir, &mod)
+		require.True(t, it.Next())
+		require.Equal(t, 1, it.Val())
+
+		mod.Add(1)
+
+		var ok bool
+		modified(t, func() {
+			ok = it.Next()
+		})
+
+		require.False(t, ok)
+		require.ErrorIs(t, it.Err(), ErrModified)
+	})
+
+	t.Run("unchecked", func(t *testing.T) {
+		t.Parallel()
+
+		it := New(ring(1, 2, 3), ForwardDir)
+		require.True(t, it.Next())
+		require.Equal(t, 1, it.Val())
+		require.NoError(t, it.Err())
+	})
+}
+
+func TestMapIter(t *testing.T) {
+	t.Parallel()
+
+	var mod atomic.Uint64
+
+	root := ring(node.Entry[string, int]{Key: "a", Val: 1}, node.Entry[string, int]{Key: "b", Val: 2})
+
+	it := NewMap(root, ReverseDir)
+	require.True(t, it.Next())
+	require.Equal(t, "b", it.Key())
+	require.Equal(t, 2, it.Val())
+
+	checked := NewCheckedMap(root, ForwardDir, &mod)
+	require.True(t, checked.Next())
+	require.Equal(t, "a", checked.Key())
+	require.Equal(t, 1, checked.Val())
+
+	mod.Add(1)
+
+	var (
+		key string
+		val int
+	)
+	modified(t, func() {
+		key = checked.Key()
+	})
+
+	val = checked.Val()
+
+	require.Zero(t, key)
+	require.Zero(t, val)
+	require.ErrorIs(t, checked.Err(), ErrModified)
+	require.False(t, checked.Next())
+}
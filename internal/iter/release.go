@@ -0,0 +1,6 @@
+//go:build !containers_debug
+
+package iter
+
+// debug makes the iterators panic on a concurrent modification of the container.
+const debug = false
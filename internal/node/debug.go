@@ -0,0 +1,150 @@
+package node
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Validate checks the ring of nodes with the sentinel root:
+// the links of the nodes are symmetric, the ring has exactly length nodes
+// and no node of the ring is in the list of free nodes of the pool.
+// The function fn, if it is not nil, is called for every node of the ring in order.
+// The zero root with nil links is a valid empty ring.
+func Validate[T any](root *Node[T], length int, p *Pool[T], fn func(i int, n *Node[T]) error) error {
+	if root.next == nil && root.prev == nil {
+		if length != 0 {
+			return fmt.Errorf("empty ring, expected %d nodes", length)
+		}
+
+		return nil
+	}
+
+	free := make(map[*Node[T]]struct{})
+	if p != nil {
+		for n := p.free; n != nil && len(free) < p.freeLen; n = n.next {
+			free[n] = struct{}{}
+		}
+	}
+
+	i := 0
+	for n := root; ; i++ {
+		next := n.next
+		switch {
+		case next == nil:
+			return fmt.Errorf("%s has nil next link", position(i-1))
+		case next.prev != n:
+			return fmt.Errorf("%s is not the previous node of its next node", position(i-1))
+		case next == root:
+			if i != length {
+				return fmt.Errorf("ring has %d nodes, expected %d", i, length)
+			}
+
+			return nil
+		case i >= length:
+			return fmt.Errorf("ring has more than %d nodes", length)
+		}
+
+		if _, ok := free[next]; ok {
+			return fmt.Errorf("node %d is in the free list", i)
+		}
+
+		if fn != nil {
+			if err := fn(i, next); err != nil {
+				return err
+			}
+		}
+
+		n = next
+	}
+}
+
+// position returns the description of the node by its index in the ring, -1 is the root.
+func position(i int) string {
+	if i < 0 {
+		return "root"
+	}
+
+	return "node " + strconv.Itoa(i)
+}
+
+// Dump writes the nodes of the ring with the sentinel root and their links, one node per line.
+// The nodes are named by their index in the ring, a link to a node outside the ring is written as its address.
+// At most limit nodes are written, so a corrupted ring does not loop forever.
+func Dump[T any](w io.Writer, root *Node[T], limit int, format func(v T) string) error {
+	nodes, names := walk(root, limit)
+
+	bw := bufio.NewWriter(w)
+	for i, n := range nodes {
+		name := names[n]
+		if i == 0 {
+			fmt.Fprintf(bw, "%s: prev=%s next=%s\n", name, link(names, n.prev), link(names, n.next))
+			continue
+		}
+
+		fmt.Fprintf(bw, "%s: prev=%s next=%s val=%s\n", name, link(names, n.prev), link(names, n.next), format(n.val))
+	}
+
+	return bw.Flush()
+}
+
+// DumpDOT writes the ring with the sentinel root as a Graphviz digraph with the name.
+// The next links are solid edges and the previous links are dashed edges.
+// At most limit nodes are written.
+func DumpDOT[T any](w io.Writer, name string, root *Node[T], limit int, format func(v T) string) error {
+	nodes, names := walk(root, limit)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "digraph %s {\n", strconv.Quote(name))
+	fmt.Fprintln(bw, "\trankdir=LR;")
+	fmt.Fprintln(bw, "\tnode [shape=box];")
+
+	for i, n := range nodes {
+		if i == 0 {
+			fmt.Fprintf(bw, "\t%s [shape=point];\n", strconv.Quote(names[n]))
+			continue
+		}
+
+		fmt.Fprintf(bw, "\t%s [label=%s];\n", strconv.Quote(names[n]), strconv.Quote(format(n.val)))
+	}
+
+	for _, n := range nodes {
+		fmt.Fprintf(bw, "\t%s -> %s;\n", strconv.Quote(names[n]), strconv.Quote(link(names, n.next)))
+		fmt.Fprintf(bw, "\t%s -> %s [style=dashed];\n", strconv.Quote(names[n]), strconv.Quote(link(names, n.prev)))
+	}
+
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
+
+// walk returns the root and at most limit nodes following it by the next links with their names.
+func walk[T any](root *Node[T], limit int) ([]*Node[T], map[*Node[T]]string) {
+	nodes := []*Node[T]{root}
+	names := map[*Node[T]]string{root: "root"}
+
+	for n := root.next; n != nil && len(nodes) <= limit; n = n.next {
+		if _, ok := names[n]; ok {
+			break
+		}
+
+		names[n] = "n" + strconv.Itoa(len(nodes)-1)
+		nodes = append(nodes, n)
+	}
+
+	return nodes, names
+}
+
+// link returns the name of the linked node, its address if the node is unnamed or nil.
+func link[T any](names map[*Node[T]]string, n *Node[T]) string {
+	if n == nil {
+		return "nil"
+	}
+
+	if name, ok := names[n]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("%p", n)
+}
@@ -0,0 +1,167 @@
+package node
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newRing(p *Pool[int], vals ...int) *Node[int] {
+	root := &Node[int]{}
+	root.SetNext(root)
+	root.SetPrev(root)
+
+	for _, v := range vals {
+		n := p.Pop()
+		n.SetVal(v)
+		root.Prev().Insert(n)
+	}
+
+	return root
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		ring   func(p *Pool[int]) (*Node[int], int)
+		expErr string
+	}{
+		{
+			name: "zero root",
+			ring: func(*Pool[int]) (*Node[int], int) {
+				return &Node[int]{}, 0
+			},
+		},
+		{
+			name: "zero root with len",
+			ring: func(*Pool[int]) (*Node[int], int) {
+				return &Node[int]{}, 1
+			},
+			expErr: "empty ring, expected 1 nodes",
+		},
+		{
+			name: "valid ring",
+			ring: func(p *Pool[int]) (*Node[int], int) {
+				return newRing(p, 1, 2, 3), 3
+			},
+		},
+		{
+			name: "broken prev link",
+			ring: func(p *Pool[int]) (*Node[int], int) {
+				root := newRing(p, 1, 2, 3)
+				root.Next().Next().SetPrev(root)
+
+				return root, 3
+			},
+			expErr: "node 0 is not the previous node of its next node",
+		},
+		{
+			name: "nil next link",
+			ring: func(p *Pool[int]) (*Node[int], int) {
+				root := newRing(p, 1, 2, 3)
+				root.Prev().SetNext(nil)
+
+				return root, 3
+			},
+			expErr: "node 2 has nil next link",
+		},
+		{
+			name: "less nodes",
+			ring: func(p *Pool[int]) (*Node[int], int) {
+				return newRing(p, 1, 2), 3
+			},
+			expErr: "ring has 2 nodes, expected 3",
+		},
+		{
+			name: "more nodes",
+			ring: func(p *Pool[int]) (*Node[int], int) {
+				return newRing(p, 1, 2, 3), 2
+			},
+			expErr: "ring has more than 2 nodes",
+		},
+		{
+			name: "free node",
+			ring: func(p *Pool[int]) (*Node[int], int) {
+				root := newRing(p, 1, 2, 3)
+				p.free = root.Next().Next()
+				p.freeLen = 1
+
+				return root, 3
+			},
+			expErr: "node 1 is in the free list",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := NewPool[int]()
+			root, length := tc.ring(p)
+
+			err := Validate(root, length, p, nil)
+			if tc.expErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.EqualError(t, err, tc.expErr)
+		})
+	}
+}
+
+func TestValidate_Visit(t *testing.T) {
+	p := NewPool[int]()
+	root := newRing(p, 1, 2, 3)
+
+	var vals []int
+	err := Validate(root, 3, p, func(i int, n *Node[int]) error {
+		require.Equal(t, len(vals), i)
+		vals = append(vals, n.Val())
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, vals)
+}
+
+func TestDump(t *testing.T) {
+	p := NewPool[int]()
+	root := newRing(p, 1, 2)
+
+	var buf bytes.Buffer
+	require.NoError(t, Dump(&buf, root, 3, strconv.Itoa))
+	require.Equal(t, `root: prev=n1 next=n0
+n0: prev=root next=n1 val=1
+n1: prev=n0 next=root val=2
+`, buf.String())
+
+	root.Next().SetPrev(nil)
+
+	buf.Reset()
+	require.NoError(t, Dump(&buf, root, 3, strconv.Itoa))
+	require.Equal(t, `root: prev=n1 next=n0
+n0: prev=nil next=n1 val=1
+n1: prev=n0 next=root val=2
+`, buf.String())
+}
+
+func TestDumpDOT(t *testing.T) {
+	p := NewPool[int]()
+	root := newRing(p, 1)
+
+	var buf bytes.Buffer
+	require.NoError(t, DumpDOT(&buf, "list", root, 2, strconv.Itoa))
+	require.Equal(t, `digraph "list" {
+	rankdir=LR;
+	node [shape=box];
+	"root" [shape=point];
+	"n0" [label="1"];
+	"root" -> "n0";
+	"root" -> "n0" [style=dashed];
+	"n0" -> "root";
+	"n0" -> "root" [style=dashed];
+}
+`, buf.String())
+}
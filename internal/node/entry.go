@@ -0,0 +1,15 @@
+package node
+
+// Entry is a key-value pair stored in the nodes of map containers.
+type Entry[K, V any] struct {
+	Key K
+	Val V
+}
+
+// Reset resets the value of the entry if the pointer to the value implements Resetter.
+// It allows the pool to reset the values of map containers.
+func (e *Entry[K, V]) Reset() {
+	if r, ok := any(&e.Val).(Resetter); ok {
+		r.Reset()
+	}
+}
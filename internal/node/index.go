@@ -0,0 +1,164 @@
+package node
+
+import (
+	"math/bits"
+)
+
+// Nil is the index of no slot.
+const Nil uint32 = 0
+
+// Slot is an element of container structures linked by indexes instead of pointers.
+// If T contains no pointers, the slots are not scanned by the garbage collector.
+type Slot[T any] struct {
+	val  T
+	next uint32
+	prev uint32
+}
+
+// Val returns the value of the slot.
+func (s *Slot[T]) Val() T {
+	return s.val
+}
+
+// SetVal sets the value of the slot.
+func (s *Slot[T]) SetVal(v T) {
+	s.val = v
+}
+
+// Next returns the index of the next slot.
+func (s *Slot[T]) Next() uint32 {
+	return s.next
+}
+
+// Prev returns the index of the previous slot.
+func (s *Slot[T]) Prev() uint32 {
+	return s.prev
+}
+
+// SetNext sets the index of the next slot.
+func (s *Slot[T]) SetNext(next uint32) {
+	s.next = next
+}
+
+// SetPrev sets the index of the previous slot.
+func (s *Slot[T]) SetPrev(prev uint32) {
+	s.prev = prev
+}
+
+// IndexPool is a pool of slots addressed by uint32 indexes.
+// The slots are stored in chunks whose sizes double, so the indexes are stable.
+// The index Nil is reserved and never returned by Pop,
+// so a pool holds at most math.MaxUint32 slots.
+type IndexPool[T any] struct {
+	chunks [][]Slot[T]
+	free   uint32
+	len    uint32
+
+	zero  bool
+	reset bool
+}
+
+// NewIndexPool returns an initialised pool with the default capacity (16).
+func NewIndexPool[T any]() *IndexPool[T] {
+	return NewIndexPoolPresized[T](defaultSize)
+}
+
+// NewIndexPoolPresized returns an initialised pool with a capacity of at least size.
+func NewIndexPoolPresized[T any](size int) *IndexPool[T] {
+	p := IndexPool[T]{
+		len:   1,
+		zero:  hasPointers[T](),
+		reset: isResetter[T](),
+	}
+
+	for p.Cap() < size+1 {
+		p.grow()
+	}
+
+	return &p
+}
+
+// Cap returns the number of allocated slots or zero if the pool is nil.
+func (p *IndexPool[T]) Cap() int {
+	if p == nil {
+		return 0
+	}
+
+	return defaultSize * ((1 << len(p.chunks)) - 1)
+}
+
+// SetZeroing sets whether the values of the slots returned to the pool are zeroed.
+// By default, only the values of types containing pointers are zeroed.
+func (p *IndexPool[T]) SetZeroing(zero bool) {
+	if p == nil {
+		return
+	}
+
+	p.zero = zero
+}
+
+// At returns the slot by index.
+// The pointer remains valid for the lifetime of the pool.
+func (p *IndexPool[T]) At(i uint32) *Slot[T] {
+	k := bits.Len32(i/defaultSize+1) - 1
+	return &p.chunks[k][i-defaultSize*(1<<k-1)]
+}
+
+// Pop returns the index of the first free slot or the index of a new slot.
+// The links of the returned slot are Nil.
+// Pop panics if all math.MaxUint32 slots are taken.
+func (p *IndexPool[T]) Pop() uint32 {
+	if p.free != Nil {
+		i := p.free
+		s := p.At(i)
+
+		p.free = s.next
+		s.next = Nil
+		return i
+	}
+
+	// The index of the next new slot wraps to Nil after the last one.
+	if p.len == Nil {
+		panic("node: index pool is exhausted")
+	}
+
+	if int(p.len) == p.Cap() {
+		p.grow()
+	}
+
+	i := p.len
+	p.len++
+	return i
+}
+
+// Push resets the value of the slot and inserts the slot into the list of free slots.
+func (p *IndexPool[T]) Push(i uint32) {
+	s := p.At(i)
+	release(&s.val, p.reset, p.zero)
+	s.next = p.free
+	s.prev = Nil
+	p.free = i
+}
+
+// Insert inserts the slot n after the slot at.
+func (p *IndexPool[T]) Insert(at, n uint32) {
+	a, s := p.At(at), p.At(n)
+	next := a.next
+
+	s.prev = at
+	s.next = next
+	p.At(next).prev = n
+	a.next = n
+}
+
+// Remove unlinks the slot n from its neighbours.
+func (p *IndexPool[T]) Remove(n uint32) {
+	s := p.At(n)
+	p.At(s.next).prev = s.prev
+	p.At(s.prev).next = s.next
+}
+
+// grow allocates the next chunk of slots.
+func (p *IndexPool[T]) grow() {
+	p.chunks = append(p.chunks, make([]Slot[T], defaultSize<<len(p.chunks)))
+}
@@ -0,0 +1,116 @@
+package node
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIndexPoolPresized(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		size   int
+		expCap int
+	}{
+		{
+			name:   "zero size",
+			expCap: defaultSize,
+		},
+		{
+			name:   "default size",
+			size:   defaultSize,
+			expCap: 3 * defaultSize,
+		},
+		{
+			name:   "large size",
+			size:   1000,
+			expCap: 63 * defaultSize,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := NewIndexPoolPresized[int](tc.size)
+			require.Equal(t, tc.expCap, p.Cap())
+			require.GreaterOrEqual(t, p.Cap(), tc.size+1)
+		})
+	}
+
+	var p *IndexPool[int]
+	require.Equal(t, 0, p.Cap())
+}
+
+func TestIndexPool_At(t *testing.T) {
+	const (
+		N = 1000
+	)
+
+	p := NewIndexPool[int]()
+	for i := 0; i < N; i++ {
+		n := p.Pop()
+		require.Equal(t, uint32(i+1), n)
+
+		p.At(n).SetVal(i)
+	}
+
+	for i := 0; i < N; i++ {
+		require.Equal(t, i, p.At(uint32(i+1)).Val())
+	}
+}
+
+func TestIndexPool_Push(t *testing.T) {
+	p := NewIndexPool[int]()
+
+	a, b := p.Pop(), p.Pop()
+	p.Push(a)
+	p.Push(b)
+
+	require.Equal(t, b, p.Pop())
+	require.Equal(t, a, p.Pop())
+	require.Equal(t, uint32(3), p.Pop())
+	require.Equal(t, Nil, p.At(a).Next())
+}
+
+func TestIndexPool_Exhausted(t *testing.T) {
+	p := NewIndexPool[int]()
+	p.len = math.MaxUint32
+
+	require.Equal(t, uint32(math.MaxUint32), p.Pop())
+	require.PanicsWithValue(t, "node: index pool is exhausted", func() {
+		p.Pop()
+	})
+
+	// A free slot is still reused.
+	p.Push(1)
+	require.Equal(t, uint32(1), p.Pop())
+}
+
+func TestIndexPool_InsertRemove(t *testing.T) {
+	p := NewIndexPool[int]()
+
+	root := p.Pop()
+	p.At(root).SetNext(root)
+	p.At(root).SetPrev(root)
+
+	a, b, c := p.Pop(), p.Pop(), p.Pop()
+	p.Insert(root, a)
+	p.Insert(a, c)
+	p.Insert(a, b)
+
+	var order []uint32
+	for n := p.At(root).Next(); n != root; n = p.At(n).Next() {
+		order = append(order, n)
+	}
+	require.Equal(t, []uint32{a, b, c}, order)
+
+	p.Remove(b)
+	require.Equal(t, c, p.At(a).Next())
+	require.Equal(t, a, p.At(c).Prev())
+
+	p.Remove(a)
+	p.Remove(c)
+	require.Equal(t, root, p.At(root).Next())
+	require.Equal(t, root, p.At(root).Prev())
+}
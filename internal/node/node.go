@@ -44,6 +44,16 @@ func (n *Node[T]) Val() T {
 	return n.val
 }
 
+// Ref returns a pointer to the value of the node or nil if the node is nil.
+// It allows containers to modify large values in place without copying them.
+func (n *Node[T]) Ref() *T {
+	if n == nil {
+		return nil
+	}
+
+	return &n.val
+}
+
 // Next returns the next node or nil if the node is nil.
 func (n *Node[T]) Next() *Node[T] {
 	if n == nil {
@@ -169,6 +169,17 @@ func TestNode_Val(t *testing.T) {
 	}
 }
 
+func TestNode_Ref(t *testing.T) {
+	var n *Node[int]
+	require.Nil(t, n.Ref())
+
+	n = &Node[int]{
+		val: 10,
+	}
+	*n.Ref() = 20
+	require.Equal(t, 20, n.Val())
+}
+
 func TestNode_Next(t *testing.T) {
 	for _, tc := range []struct {
 		name    string
@@ -5,10 +5,26 @@ const (
 )
 
 // Pool is a pool of nodes.
+// The value of a node returned to the pool is reset and zeroed,
+// so the pool does not keep the removed values reachable.
 type Pool[T any] struct {
 	free *Node[T]
 	pool []Node[T]
 	cap  int
+
+	// spare is the list of the never used nodes of the previous chunks left by Grow.
+	spare    *Node[T]
+	spareLen int
+
+	chunks  []int
+	freeLen int
+	hits    uint64
+	allocs  uint64
+
+	growth func(capacity int) int
+
+	zero  bool
+	reset bool
 }
 
 // NewPool returns an initialised pool with the default capacity (16).
@@ -18,7 +34,10 @@ func NewPool[T any]() *Pool[T] {
 
 // NewPoolPresized returns an initialised pool with a capacity of equal size.
 func NewPoolPresized[T any](size int) *Pool[T] {
-	p := Pool[T]{}
+	p := Pool[T]{
+		zero:  hasPointers[T](),
+		reset: isResetter[T](),
+	}
 	p.init(size)
 
 	return &p
@@ -33,6 +52,53 @@ func (p *Pool[T]) Cap() int {
 	return p.cap + cap(p.pool)
 }
 
+// SetZeroing sets whether the values of the nodes returned to the pool are zeroed.
+// By default, only the values of types containing pointers are zeroed,
+// because zeroing pointer-free values does not release any memory.
+func (p *Pool[T]) SetZeroing(zero bool) {
+	if p == nil {
+		return
+	}
+
+	p.zero = zero
+}
+
+// SetGrowth sets the function returning the size of the next chunk by the current capacity of the pool.
+// If the function is nil, every new chunk doubles the capacity of the pool.
+func (p *Pool[T]) SetGrowth(growth func(capacity int) int) {
+	if p == nil {
+		return
+	}
+
+	p.growth = growth
+}
+
+// Grow makes sure that n more nodes can be popped without allocation.
+// The unused nodes of the current chunk are moved to the list of spare nodes
+// and a new chunk of exactly the missing size is allocated.
+// The spare nodes are counted as allocations, not as reuse hits, when they are popped.
+func (p *Pool[T]) Grow(n int) {
+	if p == nil {
+		return
+	}
+
+	missing := n - p.freeLen - p.spareLen - (cap(p.pool) - len(p.pool))
+	if missing <= 0 {
+		return
+	}
+
+	for len(p.pool) < cap(p.pool) {
+		p.pool = append(p.pool, Node[T]{})
+
+		spare := &p.pool[len(p.pool)-1]
+		spare.SetNext(p.spare)
+		p.spare = spare
+		p.spareLen++
+	}
+
+	p.alloc(missing)
+}
+
 // Pop returns the first free node or the new node created node.
 // If the pool is nil, nil is returned.
 func (p *Pool[T]) Pop() *Node[T] {
@@ -45,36 +111,70 @@ func (p *Pool[T]) Pop() *Node[T] {
 
 		p.free = p.free.next
 		p.free.SetPrev(nil)
+		p.freeLen--
+		p.hits++
+		return n
+	}
+
+	if p.spare != nil {
+		n := p.spare
+
+		p.spare = n.next
+		n.SetNext(nil)
+		p.spareLen--
+		p.allocs++
 		return n
 	}
 
 	p.init(defaultSize)
+	p.allocs++
 	ind := len(p.pool)
 	p.pool = append(p.pool, Node[T]{})
 	return &p.pool[ind]
 }
 
-// Push inserts a node into the list of free nodes if pool is not nil.
+// Push resets the value of the node and inserts the node into the list of free nodes if pool is not nil.
 func (p *Pool[T]) Push(n *Node[T]) {
 	if p == nil {
 		return
 	}
 
+	release(&n.val, p.reset, p.zero)
 	n.SetNext(p.free)
 	n.SetPrev(nil)
 	p.free = n
+	p.freeLen++
 }
 
 // init allocates memory for a pool of nodes.
 func (p *Pool[T]) init(size int) {
-	if p.pool == nil {
-		p.pool = make([]Node[T], 0, size)
+	if p.pool == nil && size > 0 {
+		p.alloc(size)
+	}
+
+	if cap(p.pool) == len(p.pool) {
+		p.alloc(p.next())
 	}
+}
 
-	c := cap(p.pool)
+// alloc allocates a new chunk of nodes of the size.
+// The previous chunk is kept alive by the nodes taken from it.
+func (p *Pool[T]) alloc(size int) {
+	p.cap += cap(p.pool)
+	p.pool = make([]Node[T], 0, size)
+	p.chunks = append(p.chunks, size)
+}
 
-	if c == len(p.pool) {
-		p.cap += c
-		p.pool = make([]Node[T], 0, p.cap)
+// next returns the size of the next chunk according to the growth function.
+func (p *Pool[T]) next() int {
+	size := p.Cap()
+	if p.growth != nil {
+		size = p.growth(size)
 	}
+
+	if size < 1 {
+		return defaultSize
+	}
+
+	return size
 }
@@ -1,7 +1,9 @@
 package node
 
 import (
+	"strconv"
 	"testing"
+	"unsafe"
 
 	"github.com/stretchr/testify/require"
 )
@@ -63,7 +65,9 @@ func TestPool_Pop(t *testing.T) {
 			name: "empty pool",
 			p:    NewPool[int](),
 			expP: &Pool[int]{
-				pool: []Node[int]{{}},
+				pool:   []Node[int]{{}},
+				chunks: []int{defaultSize},
+				allocs: 1,
 			},
 			expNode: &Node[int]{},
 		},
@@ -73,8 +77,11 @@ func TestPool_Pop(t *testing.T) {
 				free: &Node[int]{
 					val: 10,
 				},
+				freeLen: 1,
+			},
+			expP: &Pool[int]{
+				hits: 1,
 			},
-			expP: &Pool[int]{},
 			expNode: &Node[int]{
 				val: 10,
 			},
@@ -114,6 +121,8 @@ func TestPool_Push(t *testing.T) {
 				free: &Node[int]{
 					val: 1,
 				},
+				chunks:  []int{defaultSize},
+				freeLen: 1,
 			},
 		},
 	} {
@@ -144,3 +153,109 @@ func TestPool_Cap_WithDoubleAlloc(t *testing.T) {
 	require.Equal(t, size*16, p.Cap())
 	require.Equal(t, size*8, cap(p.pool))
 }
+
+func TestPool_Stats(t *testing.T) {
+	t.Parallel()
+
+	var nilPool *Pool[int]
+	require.Equal(t, PoolStats{}, nilPool.Stats())
+
+	p := NewPoolPresized[int](2)
+
+	nodes := make([]*Node[int], 0, 5)
+	for i := 0; i < 5; i++ {
+		nodes = append(nodes, p.Pop())
+	}
+
+	p.Push(nodes[0])
+	p.Push(nodes[1])
+	p.Pop()
+
+	bytes := 8 * int64(unsafe.Sizeof(Node[int]{}))
+
+	s := p.Stats()
+	require.Equal(t, PoolStats{
+		Live:       4,
+		Free:       1,
+		Allocated:  5,
+		Capacity:   8,
+		Chunks:     3,
+		ChunkSizes: []int{2, 2, 4},
+		Hits:       1,
+		Allocs:     5,
+		Bytes:      bytes,
+	}, s)
+	require.JSONEq(t, `{
+		"live": 4,
+		"free": 1,
+		"allocated": 5,
+		"capacity": 8,
+		"chunks": 3,
+		"chunk_sizes": [2, 2, 4],
+		"hits": 1,
+		"allocs": 5,
+		"bytes": `+strconv.FormatInt(bytes, 10)+`
+	}`, s.String())
+}
+
+func TestPool_Growth(t *testing.T) {
+	t.Parallel()
+
+	p := NewPoolPresized[int](4)
+	p.SetGrowth(func(int) int {
+		return 3
+	})
+
+	for i := 0; i < 10; i++ {
+		p.Pop()
+	}
+
+	require.Equal(t, []int{4, 3, 3}, p.Stats().ChunkSizes)
+	require.Equal(t, 10, p.Cap())
+}
+
+func TestPool_Grow(t *testing.T) {
+	t.Parallel()
+
+	p := NewPoolPresized[int](4)
+	p.Push(p.Pop())
+	p.Pop()
+	p.Pop()
+
+	p.Grow(2)
+	require.Equal(t, []int{4}, p.Stats().ChunkSizes)
+
+	p.Grow(10)
+	require.Equal(t, []int{4, 8}, p.Stats().ChunkSizes)
+	require.Equal(t, 12, p.Cap())
+
+	nodes := make(map[*Node[int]]struct{})
+	for i := 0; i < 10; i++ {
+		nodes[p.Pop()] = struct{}{}
+	}
+
+	require.Len(t, nodes, 10)
+
+	s := p.Stats()
+	require.Equal(t, []int{4, 8}, s.ChunkSizes)
+	require.Equal(t, uint64(1), s.Hits)
+	require.Equal(t, uint64(12), s.Allocs)
+	require.Equal(t, 12, s.Allocated)
+	require.Equal(t, 12, s.Live)
+	require.Zero(t, s.Free)
+}
+
+func TestNewPoolPresized_Zero(t *testing.T) {
+	t.Parallel()
+
+	p := NewPoolPresized[int](0)
+
+	n := p.Pop()
+	for i := 0; i < defaultSize-1; i++ {
+		p.Pop()
+	}
+
+	n.SetVal(10)
+	require.Equal(t, 10, p.pool[0].Val())
+	require.Equal(t, []int{defaultSize}, p.Stats().ChunkSizes)
+}
@@ -0,0 +1,61 @@
+package node
+
+import (
+	"reflect"
+)
+
+// Resetter is implemented by values that release their resources when their node returns to the pool.
+// Reset is called through a pointer to the value stored in the node.
+//
+// It is never called for values of pointer types, even if the pointer type has the Reset method:
+// a removed pointer is returned to the caller, which still owns the pointed value,
+// so the pool only drops its reference by zeroing the node.
+type Resetter interface {
+	Reset()
+}
+
+// release resets the value if reset is true and zeroes it if zero is true.
+func release[T any](v *T, reset, zero bool) {
+	if reset {
+		any(v).(Resetter).Reset()
+	}
+
+	if zero {
+		var z T
+		*v = z
+	}
+}
+
+// isResetter reports whether the pointer to T implements Resetter.
+func isResetter[T any]() bool {
+	_, ok := any((*T)(nil)).(Resetter)
+	return ok
+}
+
+// hasPointers reports whether the values of T contain pointers.
+func hasPointers[T any]() bool {
+	return typeHasPointers(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// typeHasPointers reports whether the values of t contain pointers.
+func typeHasPointers(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return false
+	case reflect.Array:
+		return t.Len() > 0 && typeHasPointers(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if typeHasPointers(t.Field(i).Type) {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return true
+	}
+}
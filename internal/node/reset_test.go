@@ -0,0 +1,131 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type resetCounter struct {
+	resets *int
+}
+
+func (r *resetCounter) Reset() {
+	*r.resets++
+}
+
+func TestHasPointers(t *testing.T) {
+	type (
+		flat struct {
+			a int
+			b [4]float64
+		}
+		nested struct {
+			f flat
+			s string
+		}
+	)
+
+	require.False(t, hasPointers[int]())
+	require.False(t, hasPointers[flat]())
+	require.False(t, hasPointers[[0]*int]())
+	require.False(t, hasPointers[Entry[int, flat]]())
+	require.True(t, hasPointers[*int]())
+	require.True(t, hasPointers[string]())
+	require.True(t, hasPointers[[]int]())
+	require.True(t, hasPointers[map[int]int]())
+	require.True(t, hasPointers[any]())
+	require.True(t, hasPointers[nested]())
+	require.True(t, hasPointers[[2]nested]())
+	require.True(t, hasPointers[Entry[int, *int]]())
+}
+
+func TestPool_PushZeroing(t *testing.T) {
+	t.Run("pointers are zeroed", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPool[*int]()
+		n := p.Pop()
+		n.SetVal(new(int))
+
+		p.Push(n)
+		require.Nil(t, n.Val())
+	})
+
+	t.Run("pointer-free values are kept", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPool[int]()
+		n := p.Pop()
+		n.SetVal(10)
+
+		p.Push(n)
+		require.Equal(t, 10, n.Val())
+	})
+
+	t.Run("zeroing is enabled", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPool[int]()
+		p.SetZeroing(true)
+		n := p.Pop()
+		n.SetVal(10)
+
+		p.Push(n)
+		require.Equal(t, 0, n.Val())
+	})
+
+	t.Run("zeroing is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		v := new(int)
+		p := NewPool[*int]()
+		p.SetZeroing(false)
+		n := p.Pop()
+		n.SetVal(v)
+
+		p.Push(n)
+		require.Equal(t, v, n.Val())
+	})
+
+	t.Run("index pool", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewIndexPool[*int]()
+		i := p.Pop()
+		p.At(i).SetVal(new(int))
+
+		p.Push(i)
+		require.Nil(t, p.At(i).Val())
+	})
+}
+
+func TestPool_PushReset(t *testing.T) {
+	resets := 0
+
+	p := NewPool[resetCounter]()
+	n := p.Pop()
+	n.SetVal(resetCounter{resets: &resets})
+	p.Push(n)
+	require.Equal(t, 1, resets)
+	require.Nil(t, n.Val().resets)
+
+	e := NewPool[Entry[int, resetCounter]]()
+	en := e.Pop()
+	en.SetVal(Entry[int, resetCounter]{Key: 1, Val: resetCounter{resets: &resets}})
+	e.Push(en)
+	require.Equal(t, 2, resets)
+
+	ip := NewIndexPool[resetCounter]()
+	i := ip.Pop()
+	ip.At(i).SetVal(resetCounter{resets: &resets})
+	ip.Push(i)
+	require.Equal(t, 3, resets)
+
+	pp := NewPool[*resetCounter]()
+	pn := pp.Pop()
+	pn.SetVal(&resetCounter{resets: &resets})
+	pp.Push(pn)
+	require.Equal(t, 3, resets)
+	require.Nil(t, pn.Val())
+}
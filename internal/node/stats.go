@@ -0,0 +1,54 @@
+package node
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// PoolStats is a snapshot of the state and the counters of a pool of nodes.
+type PoolStats struct {
+	// Live is the number of nodes taken from the pool and not returned.
+	Live int `json:"live"`
+	// Free is the number of nodes in the list of free nodes.
+	Free int `json:"free"`
+	// Allocated is the number of nodes ever taken from the chunks.
+	Allocated int `json:"allocated"`
+	// Capacity is the total number of nodes of all chunks.
+	Capacity int `json:"capacity"`
+	// Chunks is the number of allocated chunks.
+	Chunks int `json:"chunks"`
+	// ChunkSizes is the number of nodes of every chunk in allocation order.
+	ChunkSizes []int `json:"chunk_sizes"`
+	// Hits is the number of nodes reused from the list of free nodes.
+	Hits uint64 `json:"hits"`
+	// Allocs is the number of nodes taken from the chunks for the first time.
+	Allocs uint64 `json:"allocs"`
+	// Bytes is the estimated memory of all chunks in bytes.
+	Bytes int64 `json:"bytes"`
+}
+
+// String returns the stats in JSON format, so the stats implement expvar.Var.
+func (s PoolStats) String() string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// Stats returns the stats of the pool or zero stats if the pool is nil.
+func (p *Pool[T]) Stats() PoolStats {
+	if p == nil {
+		return PoolStats{}
+	}
+
+	allocated := p.cap + len(p.pool) - p.spareLen
+	return PoolStats{
+		Live:       allocated - p.freeLen,
+		Free:       p.freeLen,
+		Allocated:  allocated,
+		Capacity:   p.Cap(),
+		Chunks:     len(p.chunks),
+		ChunkSizes: append([]int(nil), p.chunks...),
+		Hits:       p.hits,
+		Allocs:     p.allocs,
+		Bytes:      int64(p.Cap()) * int64(reflect.TypeOf((*Node[T])(nil)).Elem().Size()),
+	}
+}
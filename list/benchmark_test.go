@@ -2,6 +2,7 @@ package list
 
 import (
 	"container/list"
+	"sync/atomic"
 	"testing"
 )
 
@@ -139,3 +140,128 @@ func BenchmarkList_Iter(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkList_Get(b *testing.B) {
+	const (
+		N = 10000
+	)
+
+	for _, tc := range []struct {
+		name string
+		idx  func(i int) int
+	}{
+		{
+			name: "sequential",
+			idx: func(i int) int {
+				return i % N
+			},
+		},
+		{
+			name: "reverse",
+			idx: func(i int) int {
+				return N - 1 - i%N
+			},
+		},
+		{
+			name: "near sequential",
+			idx: func(i int) int {
+				return (i + i%7 - 3 + N) % N
+			},
+		},
+		{
+			name: "random",
+			idx: func(i int) int {
+				return i * 7919 % N
+			},
+		},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			l := NewPresized[int](N)
+			for i := 0; i < N; i++ {
+				l.PushBack(i)
+			}
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				l.Get(tc.idx(i))
+			}
+		})
+
+		b.Run(tc.name+" without cursor", func(b *testing.B) {
+			b.ReportAllocs()
+
+			l := NewPresized[int](N)
+			for i := 0; i < N; i++ {
+				l.PushBack(i)
+			}
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				l.cur = cursor[int]{}
+				l.Get(tc.idx(i))
+			}
+		})
+	}
+}
+
+func BenchmarkList_GetParallel(b *testing.B) {
+	const (
+		N = 10000
+	)
+
+	for _, tc := range []struct {
+		name   string
+		step   int
+		cursor bool
+	}{
+		{
+			name:   "sequential",
+			step:   1,
+			cursor: true,
+		},
+		{
+			name: "sequential without cursor",
+			step: 1,
+		},
+		{
+			name:   "random",
+			step:   7919,
+			cursor: true,
+		},
+		{
+			name: "random without cursor",
+			step: 7919,
+		},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			l := NewPresized[int](N)
+			for i := 0; i < N; i++ {
+				l.PushBack(i)
+			}
+
+			if !tc.cursor {
+				// The busy cursor makes every reader walk from the nearest end.
+				l.cm.Lock()
+				defer l.cm.Unlock()
+			}
+
+			var start atomic.Int64
+
+			b.ResetTimer()
+
+			b.RunParallel(func(pb *testing.PB) {
+				i := int(start.Add(N / 8))
+				for pb.Next() {
+					l.Get(i % N)
+					i += tc.step
+				}
+			})
+		})
+	}
+}
@@ -19,3 +19,17 @@ func ExampleNew() {
 
 	// Output: Hello World !
 }
+
+func ExampleNewBounded() {
+	l := list.NewBounded[string](2, list.DropOldest)
+
+	l.PushBack("first")
+	l.PushBack("second")
+	l.PushBack("third")
+
+	for it := l.Iter(); it.Next(); {
+		fmt.Print(it.Val(), " ")
+	}
+
+	// Output: second third
+}
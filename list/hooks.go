@@ -0,0 +1,120 @@
+package list
+
+// eventKind is the kind of a change of the list reported to the hooks.
+type eventKind uint8
+
+const (
+	insertEvent eventKind = iota
+	removeEvent
+	updateEvent
+	evictEvent
+)
+
+// event is a change of the list recorded with the list locked
+// and reported to the hooks after the list is unlocked.
+type event[T any] struct {
+	kind eventKind
+	old  T
+	val  T
+}
+
+// hooks are the functions observing the changes of the list.
+type hooks[T any] struct {
+	onInsert func(v T)
+	onRemove func(v T)
+	onUpdate func(old, v T)
+	onEvict  func(v T)
+}
+
+// OnInsert sets the function called with every value inserted into the list.
+//
+// The hooks are called after the list is unlocked in the goroutine that changed the list,
+// in the order of the changes made by the call, so they may call the list methods.
+// The hooks of concurrent calls may run concurrently.
+func (l *List[T]) OnInsert(fn func(v T)) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.hooks.onInsert = fn
+}
+
+// OnRemove sets the function called with every value removed from the list by the pop and remove methods.
+// The values dropped by the overflow policy are reported to OnEvict instead.
+// The function is called as described in OnInsert.
+func (l *List[T]) OnRemove(fn func(v T)) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.hooks.onRemove = fn
+}
+
+// OnUpdate sets the function called with the old and the new value of every element replaced by Set.
+// The function is called as described in OnInsert.
+func (l *List[T]) OnUpdate(fn func(old, v T)) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.hooks.onUpdate = fn
+}
+
+// OnEvict sets the function called with every value dropped because the bounded or sized list is full.
+// The function is called as described in OnInsert.
+func (l *List[T]) OnEvict(fn func(v T)) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.hooks.onEvict = fn
+}
+
+// notify records the change if its hook is set.
+// It must be called with the list locked for writing.
+func (l *List[T]) notify(e event[T]) {
+	if l.hooks.has(e.kind) {
+		l.events = append(l.events, e)
+	}
+}
+
+// unlock unlocks the list, wakes the pushes waiting for room
+// and calls the hooks with the changes recorded while it was locked.
+func (l *List[T]) unlock() {
+	events, h := l.events, l.hooks
+	l.events = nil
+	if l.space != nil {
+		l.space.Broadcast()
+	}
+	l.m.Unlock()
+
+	for _, e := range events {
+		h.call(e)
+	}
+}
+
+// has reports whether the hook of the kind is set.
+func (h *hooks[T]) has(kind eventKind) bool {
+	switch kind {
+	case insertEvent:
+		return h.onInsert != nil
+	case removeEvent:
+		return h.onRemove != nil
+	case updateEvent:
+		return h.onUpdate != nil
+	case evictEvent:
+		return h.onEvict != nil
+	}
+
+	return false
+}
+
+// call calls the hook of the event.
+func (h *hooks[T]) call(e event[T]) {
+	switch e.kind {
+	case insertEvent:
+		h.onInsert(e.val)
+	case removeEvent:
+		h.onRemove(e.val)
+	case updateEvent:
+		h.onUpdate(e.old, e.val)
+	case evictEvent:
+		h.onEvict(e.val)
+	}
+}
@@ -0,0 +1,233 @@
+package list
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestList_Hooks(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		l         func() *List[int]
+		change    func(l *List[int])
+		expEvents []string
+		expVals   []int
+	}{
+		{
+			name: "push",
+			l:    New[int],
+			change: func(l *List[int]) {
+				l.PushBack(1)
+				l.PushFront(0)
+				l.PushAfter(1, 2)
+			},
+			expEvents: []string{"insert 1", "insert 0", "insert 2"},
+			expVals:   []int{0, 1, 2},
+		},
+		{
+			name: "pop and remove",
+			l:    func() *List[int] { return newList(0, 1, 2, 3, 4) },
+			change: func(l *List[int]) {
+				l.PopFront()
+				l.PopBack()
+				l.Remove(1)
+				l.Remove(5)
+			},
+			expEvents: []string{"remove 0", "remove 4", "remove 2"},
+			expVals:   []int{1, 3},
+		},
+		{
+			name: "pop empty",
+			l:    New[int],
+			change: func(l *List[int]) {
+				l.PopFront()
+				l.PopBack()
+			},
+		},
+		{
+			name: "remove func",
+			l:    func() *List[int] { return newList(0, 1, 2, 3, 4) },
+			change: func(l *List[int]) {
+				l.RemoveFunc(func(v int) bool { return v%2 == 1 })
+				l.RemoveFirstFunc(func(v int) bool { return v > 0 })
+			},
+			expEvents: []string{"remove 1", "remove 3", "remove 2"},
+			expVals:   []int{0, 4},
+		},
+		{
+			name: "set",
+			l:    func() *List[int] { return newList(0, 1, 2) },
+			change: func(l *List[int]) {
+				l.Set(1, 10)
+				l.Set(3, 30)
+			},
+			expEvents: []string{"update 1 10"},
+			expVals:   []int{0, 10, 2},
+		},
+		{
+			name: "evict",
+			l: func() *List[int] {
+				l := NewBounded[int](2, DropOldest)
+				l.PushBack(0)
+				l.PushBack(1)
+				return l
+			},
+			change: func(l *List[int]) {
+				l.PushBack(2)
+			},
+			expEvents: []string{"evict 0", "insert 2"},
+			expVals:   []int{1, 2},
+		},
+		{
+			name: "drop newest",
+			l: func() *List[int] {
+				l := NewBounded[int](1, DropNewest)
+				l.PushBack(0)
+				return l
+			},
+			change: func(l *List[int]) {
+				l.PushBack(1)
+			},
+			expEvents: []string{"evict 1"},
+			expVals:   []int{0},
+		},
+		{
+			name: "reorder",
+			l:    func() *List[int] { return newList(0, 1, 2) },
+			change: func(l *List[int]) {
+				l.Reverse()
+				l.Swap(0, 2)
+				l.Rotate(1)
+			},
+			expVals: []int{2, 0, 1},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := tc.l()
+
+			var events []string
+			l.OnInsert(func(v int) {
+				events = append(events, fmt.Sprint("insert ", v))
+			})
+			l.OnRemove(func(v int) {
+				events = append(events, fmt.Sprint("remove ", v))
+			})
+			l.OnUpdate(func(old, v int) {
+				events = append(events, fmt.Sprint("update ", old, " ", v))
+			})
+			l.OnEvict(func(v int) {
+				events = append(events, fmt.Sprint("evict ", v))
+			})
+
+			tc.change(l)
+			require.Equal(t, tc.expEvents, events)
+			require.Equal(t, tc.expVals, values(l))
+		})
+	}
+}
+
+func TestList_HooksReentrant(t *testing.T) {
+	t.Parallel()
+
+	l := NewBounded[int](2, DropOldest)
+
+	var lens []int
+	l.OnEvict(func(int) {
+		lens = append(lens, l.Len())
+	})
+	l.OnRemove(func(v int) {
+		if v < 10 {
+			l.PushBack(v + 10)
+		}
+	})
+
+	for i := 1; i <= 3; i++ {
+		l.PushBack(i)
+	}
+
+	require.Equal(t, 2, l.PopFront())
+	require.Equal(t, []int{3, 12}, values(l))
+	require.Equal(t, []int{2}, lens)
+}
+
+func TestList_Set(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		i       int
+		v       string
+		expErr  error
+		expVals []string
+		expSize int64
+	}{
+		{
+			name:    "same size",
+			i:       1,
+			v:       "bb",
+			expVals: []string{"a", "bb", "ccc"},
+			expSize: 6,
+		},
+		{
+			name:    "smaller",
+			i:       2,
+			v:       "c",
+			expVals: []string{"a", "b", "c"},
+			expSize: 3,
+		},
+		{
+			name:    "fits",
+			i:       0,
+			v:       "aaaaa",
+			expVals: []string{"aaaaa", "b", "ccc"},
+			expSize: 9,
+		},
+		{
+			name:    "full",
+			i:       0,
+			v:       "aaaaaaa",
+			expErr:  ErrFull,
+			expVals: []string{"a", "b", "ccc"},
+			expSize: 5,
+		},
+		{
+			name:    "too large",
+			i:       0,
+			v:       "aaaaaaaaaaa",
+			expErr:  ErrTooLarge,
+			expVals: []string{"a", "b", "ccc"},
+			expSize: 5,
+		},
+		{
+			name:    "out of range",
+			i:       3,
+			v:       "d",
+			expVals: []string{"a", "b", "ccc"},
+			expSize: 5,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := NewSized[string](10, func(v string) int64 { return int64(len(v)) }, Reject)
+			for _, v := range []string{"a", "b", "ccc"} {
+				l.PushBack(v)
+			}
+
+			err := l.Set(tc.i, tc.v)
+			require.ErrorIs(t, err, tc.expErr)
+			var vals []string
+			for it := l.Iter(); it.Next(); {
+				vals = append(vals, it.Val())
+			}
+
+			require.Equal(t, tc.expVals, vals)
+			require.Equal(t, tc.expSize, l.Size())
+			require.NoError(t, l.Validate())
+		})
+	}
+}
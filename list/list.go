@@ -9,12 +9,50 @@
 package list
 
 import (
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
 	"sync"
+	"sync/atomic"
 
+	"github.com/glebziz/containers"
 	"github.com/glebziz/containers/internal/iter"
 	"github.com/glebziz/containers/internal/node"
 )
 
+var (
+	// ErrFull is returned by the TryPush methods when a value is pushed into a full bounded or sized list
+	// with the Reject policy, or with the Block policy in a transaction.
+	ErrFull = errors.New("list: list is full")
+	// ErrTooLarge is returned when the size of a pushed value exceeds the size limit of the list.
+	ErrTooLarge = errors.New("list: value is larger than the size limit")
+	// ErrCorrupted is returned by Validate when an invariant of the list is broken.
+	ErrCorrupted = errors.New("list: corrupted structure")
+	// ErrModified is returned by the Err method of an iterator when the list was structurally modified during the iteration.
+	ErrModified = iter.ErrModified
+)
+
+// Policy is the overflow policy of a bounded list.
+type Policy int
+
+const (
+	// DropOldest evicts the element at the opposite end of the list to free space for the pushed value:
+	// the last element for PushFront and the first element for other pushes.
+	DropOldest Policy = iota
+	// DropNewest discards the pushed value and keeps the list unchanged.
+	DropNewest
+	// Reject discards the pushed value, the TryPush methods return ErrFull.
+	Reject
+	// Block makes the push wait until other goroutines remove enough elements for the pushed value.
+	// A push in a transaction cannot wait and is rejected instead.
+	Block
+)
+
+// Stats is a snapshot of the pool of nodes of a list.
+// It implements expvar.Var by formatting itself as JSON.
+type Stats = node.PoolStats
+
 // List represents a doubly linked list.
 // The zero value for List is an empty list ready to use.
 type List[T any] struct {
@@ -22,6 +60,30 @@ type List[T any] struct {
 	pool *node.Pool[T]
 	m    sync.RWMutex
 	len  int
+
+	capacity int
+	policy   Policy
+
+	hooks  hooks[T]
+	events []event[T]
+
+	// tx is the running write transaction.
+	tx *Tx[T]
+
+	sizeFn  func(v T) int64
+	maxSize int64
+	size    int64
+
+	// space is signaled when the elements are removed from a list with the Block policy.
+	space *sync.Cond
+
+	// cur is guarded by cm, because it is updated by readers holding the read lock.
+	// A reader skips the cursor instead of waiting for cm.
+	cur cursor[T]
+	cm  sync.Mutex
+
+	// mod is the modification counter checked by the iterators.
+	mod atomic.Uint64
 }
 
 // New returns an initialized list.
@@ -42,19 +104,126 @@ func NewPresized[T any](size int) *List[T] {
 	return l
 }
 
+// NewBounded returns an initialized list that holds at most capacity elements
+// with a pool of nodes allocated for exactly that many elements.
+// Pushing into a full list is handled according to the policy.
+// If the capacity is less than one, the list holds one element.
+func NewBounded[T any](capacity int, policy Policy) *List[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	l := &List[T]{
+		pool:     node.NewPoolPresized[T](capacity),
+		capacity: capacity,
+		policy:   policy,
+	}
+	l.initPolicy()
+
+	return l
+}
+
+// NewSized returns an initialized list that keeps the total size of its elements
+// computed by sizeFn within maxSize.
+// Pushing a value that does not fit is handled according to the policy.
+// The sizeFn must return the same size for the same value during the whole time it is in the list.
+func NewSized[T any](maxSize int64, sizeFn func(v T) int64, policy Policy) *List[T] {
+	l := &List[T]{
+		pool:    node.NewPool[T](),
+		policy:  policy,
+		sizeFn:  sizeFn,
+		maxSize: maxSize,
+	}
+	l.initPolicy()
+
+	return l
+}
+
+// SetZeroing sets whether the values of the removed elements are zeroed in the pool of nodes.
+// By default, only the values of types containing pointers are zeroed.
+// If *T implements the Reset method, it is called for every removed value regardless of this setting.
+// The Reset method of a pointer type T is not called, the caller still owns the pointed value.
+func (l *List[T]) SetZeroing(zero bool) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.lazyInit()
+	l.pool.SetZeroing(zero)
+}
+
+// SetGrowth sets the growth policy of the pool of nodes.
+// If the policy is nil, every new chunk of nodes doubles the capacity of the list.
+func (l *List[T]) SetGrowth(g containers.Growth) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.lazyInit()
+	l.pool.SetGrowth(g)
+}
+
+// Grow makes sure that n more elements can be pushed without allocation.
+func (l *List[T]) Grow(n int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.lazyInit()
+	l.pool.Grow(n)
+}
+
+// Reserve makes sure that the list can hold n elements without allocation.
+func (l *List[T]) Reserve(n int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.lazyInit()
+	l.pool.Grow(n - l.len)
+}
+
+// Cap returns the maximum number of elements of a bounded list or zero if the list is unbounded.
+func (l *List[T]) Cap() int {
+	return l.capacity
+}
+
+// Size returns the total size of the elements of a sized list or zero if the list is not sized.
+func (l *List[T]) Size() int64 {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	return l.size
+}
+
+// Stats returns the stats of the pool of nodes of the list.
+func (l *List[T]) Stats() Stats {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	return l.pool.Stats()
+}
+
+// Var returns the expvar variable reporting the current stats of the list:
+//
+//	expvar.Publish("queue", l.Var())
+func (l *List[T]) Var() expvar.Var {
+	return expvar.Func(func() any {
+		return l.Stats()
+	})
+}
+
 // Len returns the number of elements of list.
 func (l *List[T]) Len() int {
 	return l.len
 }
 
 // Iter returns a list iterator with forward direction.
+// The iteration stops with ErrModified if the list is structurally modified.
 func (l *List[T]) Iter() *iter.Iter[T] {
-	return iter.New[T](&l.root, iter.ForwardDir)
+	return iter.NewChecked[T](&l.root, iter.ForwardDir, &l.mod)
 }
 
 // RIter returns a list iterator with reverse direction.
+// The iteration stops with ErrModified if the list is structurally modified.
 func (l *List[T]) RIter() *iter.Iter[T] {
-	return iter.New[T](&l.root, iter.ReverseDir)
+	return iter.NewChecked[T](&l.root, iter.ReverseDir, &l.mod)
 }
 
 // Front returns the value of the first element of the list or zero value if the list is empty.
@@ -74,7 +243,7 @@ func (l *List[T]) Back() T {
 }
 
 // Get returns the value of the i-th element of the list or zero value if the list is empty or len < i.
-// The complexity is O(n).
+// The complexity is O(n), the access close to the previously accessed index is O(1).
 func (l *List[T]) Get(i int) T {
 	l.m.RLock()
 	defer l.m.RUnlock()
@@ -83,69 +252,219 @@ func (l *List[T]) Get(i int) T {
 }
 
 // PushFront inserts a new value at the front of the list.
+// If the bounded or sized list is full, its overflow policy is applied,
+// a rejected value is discarded silently.
 func (l *List[T]) PushFront(v T) {
+	_ = l.TryPushFront(v)
+}
+
+// PushBack inserts a new value at the back of the list.
+// If the bounded or sized list is full, its overflow policy is applied,
+// a rejected value is discarded silently.
+func (l *List[T]) PushBack(v T) {
+	_ = l.TryPushBack(v)
+}
+
+// PushAfter inserts a new value after the i-th element of the list.
+// If the bounded or sized list is full, its overflow policy is applied,
+// a rejected value is discarded silently.
+func (l *List[T]) PushAfter(i int, v T) {
+	_ = l.TryPushAfter(i, v)
+}
+
+// PushBefore inserts a new value before the i-th element of the list.
+// If the bounded or sized list is full, its overflow policy is applied,
+// a rejected value is discarded silently.
+func (l *List[T]) PushBefore(i int, v T) {
+	_ = l.TryPushBefore(i, v)
+}
+
+// TryPushFront inserts a new value at the front of the list.
+// ErrFull is returned if the bounded or sized list is full and its policy is Reject.
+// ErrTooLarge is returned if the size of the value exceeds the size limit of the list.
+func (l *List[T]) TryPushFront(v T) error {
 	l.m.Lock()
-	defer l.m.Unlock()
+	defer l.unlock()
 
 	l.lazyInit()
-	l.insert(v, &l.root)
+	l.wait(v)
+	return l.insert(v, &l.root, true)
 }
 
-// PushBack inserts a new value at the back of the list.
-func (l *List[T]) PushBack(v T) {
+// TryPushBack inserts a new value at the back of the list.
+// ErrFull is returned if the bounded or sized list is full and its policy is Reject.
+// ErrTooLarge is returned if the size of the value exceeds the size limit of the list.
+func (l *List[T]) TryPushBack(v T) error {
 	l.m.Lock()
-	defer l.m.Unlock()
+	defer l.unlock()
 
 	l.lazyInit()
-	l.insert(v, l.root.Prev())
+	l.wait(v)
+	return l.insert(v, l.root.Prev(), false)
 }
 
-// PushAfter inserts a new value after the i-th element of the list.
-func (l *List[T]) PushAfter(i int, v T) {
+// TryPushAfter inserts a new value after the i-th element of the list.
+// ErrFull is returned if the bounded or sized list is full and its policy is Reject.
+// ErrTooLarge is returned if the size of the value exceeds the size limit of the list.
+func (l *List[T]) TryPushAfter(i int, v T) error {
 	l.m.Lock()
-	defer l.m.Unlock()
+	defer l.unlock()
 
-	l.insert(v, l.get(i))
+	l.wait(v)
+	return l.insert(v, l.get(i), false)
 }
 
-// PushBefore inserts a new value before the i-th element of the list.
-func (l *List[T]) PushBefore(i int, v T) {
+// TryPushBefore inserts a new value before the i-th element of the list.
+// ErrFull is returned if the bounded or sized list is full and its policy is Reject.
+// ErrTooLarge is returned if the size of the value exceeds the size limit of the list.
+func (l *List[T]) TryPushBefore(i int, v T) error {
 	l.m.Lock()
-	defer l.m.Unlock()
+	defer l.unlock()
 
-	l.insert(v, l.get(i).Prev())
+	l.wait(v)
+	return l.insert(v, l.get(i).Prev(), false)
 }
 
 // PopFront returns and removes the first element of the list if the list is not empty.
 func (l *List[T]) PopFront() T {
 	l.m.Lock()
-	defer l.m.Unlock()
-
-	v := l.root.Next().Val()
-	l.remove(l.root.Next())
+	defer l.unlock()
 
-	return v
+	return l.pop(l.root.Next())
 }
 
 // PopBack returns and removes the last element of the list if the list is not empty.
 func (l *List[T]) PopBack() T {
 	l.m.Lock()
-	defer l.m.Unlock()
+	defer l.unlock()
 
-	v := l.root.Prev().Val()
-	l.remove(l.root.Prev())
-
-	return v
+	return l.pop(l.root.Prev())
 }
 
 // Remove removes the i-th element of the list if the i is less than len.
 func (l *List[T]) Remove(i int) {
 	l.m.Lock()
-	defer l.m.Unlock()
+	defer l.unlock()
 
 	l.remove(l.get(i))
 }
 
+// Set replaces the value of the i-th element of the list if the i is less than len.
+// ErrTooLarge is returned if the size of the value exceeds the size limit of the list.
+// ErrFull is returned if the sized list cannot hold the value in place of the replaced one.
+// The complexity is O(n).
+func (l *List[T]) Set(i int, v T) error {
+	l.m.Lock()
+	defer l.unlock()
+
+	return l.set(i, v)
+}
+
+// Reverse reverses the order of the elements of the list in place.
+// The complexity is O(n).
+func (l *List[T]) Reverse() {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.reverse()
+}
+
+// Rotate rotates the list by n positions.
+// A positive n moves the last n elements to the front of the list,
+// a negative n moves the first -n elements to the back of the list.
+// The complexity is O(min(n, len-n)).
+func (l *List[T]) Rotate(n int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.rotate(n)
+}
+
+// Swap swaps the i-th and the j-th elements of the list
+// if both indexes are less than len.
+// The complexity is O(n).
+func (l *List[T]) Swap(i, j int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.swap(i, j)
+}
+
+// Move moves the element at index from so that it ends up at index to
+// if both indexes are less than len.
+// The complexity is O(n).
+func (l *List[T]) Move(from, to int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.move(from, to)
+}
+
+// Validate checks the invariants of the list: the links of the nodes are symmetric,
+// the len matches the number of nodes, no node of the list is free in the pool,
+// the size of a sized list and the cursor of the last accessed node are consistent.
+// The complexity is O(n).
+func (l *List[T]) Validate() error {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	l.cm.Lock()
+	defer l.cm.Unlock()
+
+	var (
+		size   int64
+		cursor bool
+	)
+	err := node.Validate(&l.root, l.len, l.pool, func(i int, n *node.Node[T]) error {
+		size += l.sizeOf(n.Val())
+
+		if n == l.cur.n {
+			if i != l.cur.i {
+				return fmt.Errorf("cursor index is %d, expected %d", l.cur.i, i)
+			}
+
+			cursor = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCorrupted, err)
+	}
+
+	switch {
+	case l.cur.n != nil && !cursor:
+		return fmt.Errorf("%w: cursor node is not in the list", ErrCorrupted)
+	case size != l.size:
+		return fmt.Errorf("%w: size is %d, expected %d", ErrCorrupted, l.size, size)
+	case l.capacity > 0 && l.len > l.capacity:
+		return fmt.Errorf("%w: len %d exceeds capacity %d", ErrCorrupted, l.len, l.capacity)
+	}
+
+	return nil
+}
+
+// Dump writes the len of the list and its nodes with their links to w for debugging.
+// At most len+1 nodes are written, so a corrupted list does not loop forever.
+func (l *List[T]) Dump(w io.Writer) error {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	if _, err := fmt.Fprintf(w, "len=%d size=%d\n", l.len, l.size); err != nil {
+		return err
+	}
+
+	return node.Dump(w, &l.root, l.len+1, format[T])
+}
+
+// DumpDOT writes the nodes of the list with their links to w as a Graphviz digraph for debugging.
+func (l *List[T]) DumpDOT(w io.Writer) error {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	return node.DumpDOT(w, "list", &l.root, l.len+1, format[T])
+}
+
 // lazyInit lazily initializes a zero List value.
 func (l *List[T]) lazyInit() {
 	if l.pool == nil {
@@ -158,57 +477,343 @@ func (l *List[T]) lazyInit() {
 	}
 }
 
+// initPolicy prepares the list for its overflow policy.
+func (l *List[T]) initPolicy() {
+	if l.policy == Block {
+		l.space = sync.NewCond(&l.m)
+	}
+}
+
+// wait waits until the list with the Block policy has room for the value.
+// The list is unlocked while waiting, so the insertion point must be found after the wait.
+// A value larger than the size limit is not waited for, and the wait is skipped in a transaction.
+func (l *List[T]) wait(v T) {
+	if l.space == nil || l.tx != nil {
+		return
+	}
+
+	size := l.sizeOf(v)
+	if l.maxSize > 0 && size > l.maxSize {
+		return
+	}
+
+	for l.full(size) {
+		l.space.Wait()
+	}
+}
+
 // insert inserts node with value v after at, increments len.
-func (l *List[T]) insert(v T, at *node.Node[T]) {
+// If the bounded or sized list is full, the overflow policy is applied first,
+// evicting elements from the back if evictBack is true and from the front otherwise.
+func (l *List[T]) insert(v T, at *node.Node[T], evictBack bool) error {
 	if at == nil {
-		return
+		return nil
+	}
+
+	size := l.sizeOf(v)
+	if l.maxSize > 0 && size > l.maxSize {
+		return ErrTooLarge
+	}
+
+	for l.full(size) {
+		switch l.policy {
+		case Reject, Block:
+			return ErrFull
+		case DropNewest:
+			l.notify(event[T]{kind: evictEvent, val: v})
+			return nil
+		}
+
+		victim := l.root.Next()
+		if evictBack {
+			victim = l.root.Prev()
+		}
+
+		if victim == at {
+			at = victim.Prev()
+		}
+
+		l.notify(event[T]{kind: evictEvent, val: victim.Val()})
+		l.unlink(victim)
 	}
 
 	n := l.pool.Pop()
 	n.SetVal(v)
 	at.Insert(n)
 	l.len++
+	l.size += size
+	l.modified()
+
+	if l.tx != nil {
+		l.undo(func() {
+			l.size -= size
+			n.Remove()
+			l.pool.Push(n)
+			l.len--
+			l.modified()
+		})
+	}
+
+	l.notify(event[T]{kind: insertEvent, val: v})
+
+	return nil
+}
+
+// full reports whether a value of the size does not fit into the list.
+func (l *List[T]) full(size int64) bool {
+	return (l.capacity > 0 && l.len >= l.capacity) ||
+		(l.maxSize > 0 && l.size+size > l.maxSize)
+}
+
+// sizeOf returns the size of the value or zero if the list is not sized.
+func (l *List[T]) sizeOf(v T) int64 {
+	if l.sizeFn == nil {
+		return 0
+	}
+
+	return l.sizeFn(v)
 }
 
-// remove removes n from list, decrements len.
+// set replaces the value of the i-th element of the list.
+func (l *List[T]) set(i int, v T) error {
+	n := l.get(i)
+	if n == nil {
+		return nil
+	}
+
+	old := n.Val()
+	size := l.sizeOf(v)
+	switch {
+	case l.maxSize > 0 && size > l.maxSize:
+		return ErrTooLarge
+	case l.maxSize > 0 && l.size-l.sizeOf(old)+size > l.maxSize:
+		return ErrFull
+	}
+
+	n.SetVal(v)
+	l.size += size - l.sizeOf(old)
+
+	if l.tx != nil {
+		l.undo(func() {
+			n.SetVal(old)
+			l.size += l.sizeOf(old) - size
+		})
+	}
+
+	l.notify(event[T]{kind: updateEvent, old: old, val: v})
+
+	return nil
+}
+
+// reverse reverses the order of the elements of the list.
+func (l *List[T]) reverse() {
+	if l.len < 2 {
+		return
+	}
+
+	l.modified()
+
+	if l.tx != nil {
+		l.undo(l.reverse)
+	}
+
+	n := &l.root
+	for {
+		next := n.Next()
+		n.SetNext(n.Prev())
+		n.SetPrev(next)
+
+		n = next
+		if n == &l.root {
+			break
+		}
+	}
+}
+
+// rotate rotates the list by n positions.
+func (l *List[T]) rotate(n int) {
+	if l.len < 2 {
+		return
+	}
+
+	n %= l.len
+	if n == 0 {
+		return
+	}
+
+	if n < 0 {
+		n += l.len
+	}
+
+	front := l.get(l.len - n)
+	l.root.Remove()
+	front.Prev().Insert(&l.root)
+	l.modified()
+
+	if l.tx != nil {
+		l.undo(func() { l.rotate(-n) })
+	}
+}
+
+// swap swaps the i-th and the j-th elements of the list.
+func (l *List[T]) swap(i, j int) {
+	if i == j {
+		return
+	}
+
+	if i > j {
+		i, j = j, i
+	}
+
+	a, b := l.get(i), l.get(j)
+	if a == nil || b == nil {
+		return
+	}
+
+	prev := a.Prev()
+	a.Remove()
+	b.Insert(a)
+	b.Remove()
+	prev.Insert(b)
+	l.modified()
+
+	if l.tx != nil {
+		l.undo(func() { l.swap(i, j) })
+	}
+}
+
+// move moves the element at index from to index to.
+func (l *List[T]) move(from, to int) {
+	if from == to {
+		return
+	}
+
+	n, at := l.get(from), l.get(to)
+	if n == nil || at == nil {
+		return
+	}
+
+	if from > to {
+		at = at.Prev()
+	}
+
+	n.Remove()
+	at.Insert(n)
+	l.modified()
+
+	if l.tx != nil {
+		l.undo(func() { l.move(to, from) })
+	}
+}
+
+// pop removes n from list and returns its value.
+func (l *List[T]) pop(n *node.Node[T]) T {
+	v := n.Val()
+	l.remove(n)
+
+	return v
+}
+
+// remove removes n from list and reports the removed value to the hooks.
 func (l *List[T]) remove(n *node.Node[T]) {
 	if n == nil || l.len <= 0 {
 		return
 	}
 
+	l.notify(event[T]{kind: removeEvent, val: n.Val()})
+	l.unlink(n)
+}
+
+// unlink removes n from list, decrements len.
+// In a transaction the node is kept out of the pool until the commit.
+func (l *List[T]) unlink(n *node.Node[T]) {
+	size, prev := l.sizeOf(n.Val()), n.Prev()
+
+	l.size -= size
 	n.Remove()
-	l.pool.Push(n)
 	l.len--
+	l.modified()
+
+	if l.tx == nil {
+		l.pool.Push(n)
+		return
+	}
+
+	l.tx.free = append(l.tx.free, n)
+	l.undo(func() {
+		prev.Insert(n)
+		l.len++
+		l.size += size
+		l.modified()
+	})
 }
 
 // get returns the i-th node or nil if the index is less than zero or greater than the len of the list.
+// The walk starts from the nearest of the front, the back and the cursor of the last accessed node,
+// so the sequential access has O(1) amortized complexity.
+// A reader that finds the cursor used by another reader walks from the nearest end without it,
+// so the concurrent readers are not serialized.
 func (l *List[T]) get(i int) *node.Node[T] {
 	if l.len <= i || i < 0 {
 		return nil
 	}
 
-	var (
-		n    *node.Node[T]
-		next func() *node.Node[T]
-	)
-	if i > l.len/2 {
-		i = l.len - i
-		n = l.root.Prev()
-		next = func() *node.Node[T] {
-			return n.Prev()
-		}
+	n, at := l.root.Next(), 0
+	if l.len-1-i < i {
+		n, at = l.root.Prev(), l.len-1
+	}
 
-	} else {
-		n = l.root.Next()
-		next = func() *node.Node[T] {
-			return n.Next()
-		}
+	if !l.cm.TryLock() {
+		return walk(n, at, i)
 	}
+	defer l.cm.Unlock()
 
-	for i > 0 {
-		n = next()
-		i--
+	if l.cur.n != nil && abs(i-l.cur.i) < abs(i-at) {
+		n, at = l.cur.n, l.cur.i
 	}
 
+	n = walk(n, at, i)
+	l.cur = cursor[T]{n: n, i: i}
+
 	return n
 }
+
+// walk returns the i-th node starting from the node n at the index at.
+func walk[T any](n *node.Node[T], at, i int) *node.Node[T] {
+	for ; at < i; at++ {
+		n = n.Next()
+	}
+
+	for ; at > i; at-- {
+		n = n.Prev()
+	}
+
+	return n
+}
+
+// modified increments the modification counter and invalidates the cursor after a structural change of the list.
+// It must be called with the list locked for writing.
+func (l *List[T]) modified() {
+	l.cur = cursor[T]{}
+	l.mod.Add(1)
+}
+
+// cursor is the last accessed node with its index.
+type cursor[T any] struct {
+	n *node.Node[T]
+	i int
+}
+
+// format returns the default format of the value.
+func format[T any](v T) string {
+	return fmt.Sprint(v)
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+
+	return x
+}
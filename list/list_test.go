@@ -1,10 +1,15 @@
 package list
 
 import (
+	"bytes"
+	"runtime"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/glebziz/containers"
 	"github.com/glebziz/containers/internal/node"
 )
 
@@ -178,7 +183,7 @@ func TestList_Get(t *testing.T) {
 
 				return l
 			},
-			expVal: 30,
+			expVal: 40,
 		},
 		{
 			name: "index out of range",
@@ -217,6 +222,80 @@ func TestList_Get(t *testing.T) {
 	}
 }
 
+func TestList_GetCursor(t *testing.T) {
+	const (
+		N = 100
+	)
+
+	l := NewPresized[int](N)
+	for i := 0; i < N; i++ {
+		l.PushBack(i)
+	}
+
+	for _, idx := range [][]int{
+		{0, 1, 2, 3, 50, 51, 49, 48, 99, 98, 97},
+		{30, 60, 31, 61, 32, 62},
+		{70, 10, 70, 90, 0},
+	} {
+		for _, i := range idx {
+			require.Equal(t, i, l.Get(i))
+		}
+	}
+
+	l.PushFront(-1)
+	require.Equal(t, -1, l.Get(0))
+	require.Equal(t, 0, l.Get(1))
+
+	l.Remove(0)
+	require.Equal(t, 0, l.Get(0))
+	require.Equal(t, 1, l.Get(1))
+
+	l.Reverse()
+	require.Equal(t, 98, l.Get(1))
+	require.Equal(t, 97, l.Get(2))
+
+	l.Rotate(1)
+	require.Equal(t, 98, l.Get(2))
+
+	l.Swap(0, 2)
+	require.Equal(t, 0, l.Get(2))
+
+	l.Move(2, 3)
+	require.Equal(t, 0, l.Get(3))
+
+	l.RemoveFunc(func(v int) bool {
+		return v%2 == 0
+	})
+	require.Equal(t, 99, l.Get(0))
+	require.Equal(t, 97, l.Get(1))
+}
+
+func TestList_GetConcurrent(t *testing.T) {
+	const (
+		N = 1000
+		G = 8
+	)
+
+	l := NewPresized[int](N)
+	for i := 0; i < N; i++ {
+		l.PushBack(i)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < G; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+
+			for i := g; i < N; i += G {
+				require.Equal(t, i, l.Get(i))
+			}
+		}(g)
+	}
+
+	wg.Wait()
+}
+
 func TestList_PushFront(t *testing.T) {
 	for _, tc := range []struct {
 		name      string
@@ -675,3 +754,738 @@ func TestList_Remove(t *testing.T) {
 		})
 	}
 }
+
+func TestList_Reverse(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		l       func() *List[int]
+		expVals []int
+	}{
+		{
+			name: "zero list",
+			l: func() *List[int] {
+				return &List[int]{}
+			},
+		},
+		{
+			name: "one value",
+			l: func() *List[int] {
+				return newList(1)
+			},
+			expVals: []int{1},
+		},
+		{
+			name: "more values",
+			l: func() *List[int] {
+				return newList(1, 2, 3, 4)
+			},
+			expVals: []int{4, 3, 2, 1},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := tc.l()
+			l.Reverse()
+			require.Equal(t, tc.expVals, values(l))
+			require.Equal(t, len(tc.expVals), l.Len())
+		})
+	}
+}
+
+func TestList_Rotate(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		n       int
+		l       func() *List[int]
+		expVals []int
+	}{
+		{
+			name: "zero list",
+			n:    1,
+			l: func() *List[int] {
+				return &List[int]{}
+			},
+		},
+		{
+			name: "positive n",
+			n:    1,
+			l: func() *List[int] {
+				return newList(1, 2, 3, 4)
+			},
+			expVals: []int{4, 1, 2, 3},
+		},
+		{
+			name: "negative n",
+			n:    -1,
+			l: func() *List[int] {
+				return newList(1, 2, 3, 4)
+			},
+			expVals: []int{2, 3, 4, 1},
+		},
+		{
+			name: "n greater than len",
+			n:    6,
+			l: func() *List[int] {
+				return newList(1, 2, 3, 4)
+			},
+			expVals: []int{3, 4, 1, 2},
+		},
+		{
+			name: "n equal to len",
+			n:    4,
+			l: func() *List[int] {
+				return newList(1, 2, 3, 4)
+			},
+			expVals: []int{1, 2, 3, 4},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := tc.l()
+			l.Rotate(tc.n)
+			require.Equal(t, tc.expVals, values(l))
+			require.Equal(t, tc.expVals, rvalues(l))
+		})
+	}
+}
+
+func TestList_Swap(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		i, j    int
+		l       func() *List[int]
+		expVals []int
+	}{
+		{
+			name: "zero list",
+			i:    0,
+			j:    1,
+			l: func() *List[int] {
+				return &List[int]{}
+			},
+		},
+		{
+			name: "same index",
+			i:    1,
+			j:    1,
+			l: func() *List[int] {
+				return newList(1, 2, 3)
+			},
+			expVals: []int{1, 2, 3},
+		},
+		{
+			name: "adjacent elements",
+			i:    2,
+			j:    1,
+			l: func() *List[int] {
+				return newList(1, 2, 3)
+			},
+			expVals: []int{1, 3, 2},
+		},
+		{
+			name: "first and last elements",
+			i:    0,
+			j:    3,
+			l: func() *List[int] {
+				return newList(1, 2, 3, 4)
+			},
+			expVals: []int{4, 2, 3, 1},
+		},
+		{
+			name: "index out of range",
+			i:    0,
+			j:    10,
+			l: func() *List[int] {
+				return newList(1, 2, 3)
+			},
+			expVals: []int{1, 2, 3},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := tc.l()
+			l.Swap(tc.i, tc.j)
+			require.Equal(t, tc.expVals, values(l))
+			require.Equal(t, tc.expVals, rvalues(l))
+		})
+	}
+}
+
+func TestList_Move(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		from, to int
+		l        func() *List[int]
+		expVals  []int
+	}{
+		{
+			name: "zero list",
+			from: 0,
+			to:   1,
+			l: func() *List[int] {
+				return &List[int]{}
+			},
+		},
+		{
+			name: "forward",
+			from: 1,
+			to:   3,
+			l: func() *List[int] {
+				return newList(1, 2, 3, 4)
+			},
+			expVals: []int{1, 3, 4, 2},
+		},
+		{
+			name: "backward",
+			from: 3,
+			to:   0,
+			l: func() *List[int] {
+				return newList(1, 2, 3, 4)
+			},
+			expVals: []int{4, 1, 2, 3},
+		},
+		{
+			name: "adjacent elements",
+			from: 1,
+			to:   2,
+			l: func() *List[int] {
+				return newList(1, 2, 3, 4)
+			},
+			expVals: []int{1, 3, 2, 4},
+		},
+		{
+			name: "index out of range",
+			from: 0,
+			to:   4,
+			l: func() *List[int] {
+				return newList(1, 2, 3, 4)
+			},
+			expVals: []int{1, 2, 3, 4},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := tc.l()
+			l.Move(tc.from, tc.to)
+			require.Equal(t, tc.expVals, values(l))
+			require.Equal(t, tc.expVals, rvalues(l))
+		})
+	}
+}
+
+// newList returns a list with the values vals.
+func newList(vals ...int) *List[int] {
+	l := NewPresized[int](len(vals))
+	for _, v := range vals {
+		l.PushBack(v)
+	}
+
+	return l
+}
+
+// values returns the values of the list in forward order.
+func values(l *List[int]) []int {
+	var vals []int
+	for it := l.Iter(); it.Next(); {
+		vals = append(vals, it.Val())
+	}
+
+	return vals
+}
+
+// rvalues returns the values of the list walking it in reverse order.
+func rvalues(l *List[int]) []int {
+	var vals []int
+	for it := l.RIter(); it.Next(); {
+		vals = append([]int{it.Val()}, vals...)
+	}
+
+	return vals
+}
+
+func TestNewBounded(t *testing.T) {
+	l := NewBounded[int](3, Reject)
+	require.Equal(t, 3, l.Cap())
+	require.Equal(t, 3, l.pool.Cap())
+
+	l = NewBounded[int](0, Reject)
+	require.Equal(t, 1, l.Cap())
+	require.Zero(t, New[int]().Cap())
+}
+
+func TestList_Bounded(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		policy     Policy
+		push       func(l *List[int]) error
+		expErr     error
+		expVals    []int
+		expEvicted []int
+	}{
+		{
+			name:   "drop oldest with push back",
+			policy: DropOldest,
+			push: func(l *List[int]) error {
+				return l.TryPushBack(4)
+			},
+			expVals:    []int{2, 3, 4},
+			expEvicted: []int{1},
+		},
+		{
+			name:   "drop oldest with push front",
+			policy: DropOldest,
+			push: func(l *List[int]) error {
+				return l.TryPushFront(0)
+			},
+			expVals:    []int{0, 1, 2},
+			expEvicted: []int{3},
+		},
+		{
+			name:   "drop oldest with push after the first element",
+			policy: DropOldest,
+			push: func(l *List[int]) error {
+				return l.TryPushAfter(0, 10)
+			},
+			expVals:    []int{10, 2, 3},
+			expEvicted: []int{1},
+		},
+		{
+			name:   "drop oldest with push before",
+			policy: DropOldest,
+			push: func(l *List[int]) error {
+				return l.TryPushBefore(2, 10)
+			},
+			expVals:    []int{2, 10, 3},
+			expEvicted: []int{1},
+		},
+		{
+			name:   "drop oldest with index out of range",
+			policy: DropOldest,
+			push: func(l *List[int]) error {
+				return l.TryPushAfter(10, 10)
+			},
+			expVals: []int{1, 2, 3},
+		},
+		{
+			name:   "drop newest",
+			policy: DropNewest,
+			push: func(l *List[int]) error {
+				return l.TryPushBack(4)
+			},
+			expVals:    []int{1, 2, 3},
+			expEvicted: []int{4},
+		},
+		{
+			name:   "reject",
+			policy: Reject,
+			push: func(l *List[int]) error {
+				return l.TryPushFront(0)
+			},
+			expErr:  ErrFull,
+			expVals: []int{1, 2, 3},
+		},
+		{
+			name:   "reject without error",
+			policy: Reject,
+			push: func(l *List[int]) error {
+				l.PushBack(4)
+				return nil
+			},
+			expVals: []int{1, 2, 3},
+		},
+		{
+			name:   "block in transaction",
+			policy: Block,
+			push: func(l *List[int]) error {
+				return l.Update(func(tx *Tx[int]) error {
+					return tx.TryPushBack(4)
+				})
+			},
+			expErr:  ErrFull,
+			expVals: []int{1, 2, 3},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := NewBounded[int](3, tc.policy)
+			for i := 1; i <= 3; i++ {
+				l.PushBack(i)
+			}
+
+			var evicted []int
+			l.OnEvict(func(v int) {
+				evicted = append(evicted, v)
+			})
+
+			err := tc.push(l)
+			require.ErrorIs(t, err, tc.expErr)
+			require.Equal(t, tc.expVals, values(l))
+			require.Equal(t, tc.expVals, rvalues(l))
+			require.Equal(t, tc.expEvicted, evicted)
+			require.Equal(t, 3, l.Len())
+			require.Equal(t, 3, l.pool.Cap())
+		})
+	}
+}
+
+func TestList_Block(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		l       func() *List[int]
+		push    func(l *List[int])
+		expVals []int
+	}{
+		{
+			name: "bounded",
+			l: func() *List[int] {
+				return NewBounded[int](2, Block)
+			},
+			push: func(l *List[int]) {
+				l.PushBack(3)
+			},
+			expVals: []int{2, 3},
+		},
+		{
+			name: "sized",
+			l: func() *List[int] {
+				return NewSized[int](3, func(v int) int64 {
+					return int64(v)
+				}, Block)
+			},
+			push: func(l *List[int]) {
+				l.PushAfter(0, 1)
+			},
+			expVals: []int{2, 1},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := tc.l()
+			l.PushBack(1)
+			l.PushBack(2)
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				tc.push(l)
+			}()
+
+			select {
+			case <-done:
+				require.Fail(t, "push into a full list is not blocked")
+			case <-time.After(10 * time.Millisecond):
+			}
+
+			require.Equal(t, 1, l.PopFront())
+			<-done
+
+			require.Equal(t, tc.expVals, values(l))
+			require.NoError(t, l.Validate())
+		})
+	}
+}
+
+func TestList_Sized(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		policy     Policy
+		push       func(l *List[string]) error
+		expErr     error
+		expVals    []string
+		expEvicted []string
+		expSize    int64
+	}{
+		{
+			name:   "fits",
+			policy: Reject,
+			push: func(l *List[string]) error {
+				return l.TryPushBack("d")
+			},
+			expVals: []string{"aa", "bbb", "d"},
+			expSize: 6,
+		},
+		{
+			name:   "drop oldest evicts several values",
+			policy: DropOldest,
+			push: func(l *List[string]) error {
+				return l.TryPushBack("dddd")
+			},
+			expVals:    []string{"dddd"},
+			expEvicted: []string{"aa", "bbb"},
+			expSize:    4,
+		},
+		{
+			name:   "drop oldest with push front",
+			policy: DropOldest,
+			push: func(l *List[string]) error {
+				return l.TryPushFront("dd")
+			},
+			expVals:    []string{"dd", "aa"},
+			expEvicted: []string{"bbb"},
+			expSize:    4,
+		},
+		{
+			name:   "drop newest",
+			policy: DropNewest,
+			push: func(l *List[string]) error {
+				return l.TryPushBack("dd")
+			},
+			expVals:    []string{"aa", "bbb"},
+			expEvicted: []string{"dd"},
+			expSize:    5,
+		},
+		{
+			name:   "reject",
+			policy: Reject,
+			push: func(l *List[string]) error {
+				return l.TryPushBack("dd")
+			},
+			expErr:  ErrFull,
+			expVals: []string{"aa", "bbb"},
+			expSize: 5,
+		},
+		{
+			name:   "too large",
+			policy: DropOldest,
+			push: func(l *List[string]) error {
+				return l.TryPushBack("ddddddd")
+			},
+			expErr:  ErrTooLarge,
+			expVals: []string{"aa", "bbb"},
+			expSize: 5,
+		},
+		{
+			name:   "pop",
+			policy: DropOldest,
+			push: func(l *List[string]) error {
+				l.PopFront()
+				return nil
+			},
+			expVals: []string{"bbb"},
+			expSize: 3,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := NewSized[string](6, func(v string) int64 {
+				return int64(len(v))
+			}, tc.policy)
+			l.PushBack("aa")
+			l.PushBack("bbb")
+
+			var evicted []string
+			l.OnEvict(func(v string) {
+				evicted = append(evicted, v)
+			})
+
+			err := tc.push(l)
+			require.ErrorIs(t, err, tc.expErr)
+			require.Equal(t, tc.expEvicted, evicted)
+			require.Equal(t, tc.expSize, l.Size())
+			require.Equal(t, len(tc.expVals), l.Len())
+
+			var vals []string
+			for it := l.Iter(); it.Next(); {
+				vals = append(vals, it.Val())
+			}
+
+			require.Equal(t, tc.expVals, vals)
+		})
+	}
+}
+
+func TestList_ReleaseValues(t *testing.T) {
+	type big struct {
+		buf [1 << 10]byte
+	}
+
+	l := New[*big]()
+
+	released := make(chan struct{})
+	v := &big{}
+	runtime.SetFinalizer(v, func(*big) {
+		close(released)
+	})
+
+	l.PushBack(v)
+	l.PushBack(&big{})
+	l.PopFront()
+	v = nil
+
+	defer runtime.KeepAlive(l)
+
+	for i := 0; i < 10; i++ {
+		runtime.GC()
+
+		select {
+		case <-released:
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	t.Fatal("popped value is still reachable from the pool")
+}
+
+func TestList_Stats(t *testing.T) {
+	var z List[int]
+	require.Equal(t, Stats{}, z.Stats())
+
+	l := NewPresized[int](4)
+	for i := 0; i < 6; i++ {
+		l.PushBack(i)
+	}
+
+	l.PopFront()
+	l.PopFront()
+	l.PushBack(6)
+
+	s := l.Stats()
+	require.Equal(t, l.Len(), s.Live)
+	require.Equal(t, 1, s.Free)
+	require.Equal(t, 6, s.Allocated)
+	require.Equal(t, 8, s.Capacity)
+	require.Equal(t, []int{4, 4}, s.ChunkSizes)
+	require.Equal(t, uint64(1), s.Hits)
+	require.Equal(t, uint64(6), s.Allocs)
+	require.Positive(t, s.Bytes)
+
+	require.JSONEq(t, s.String(), l.Var().String())
+}
+
+func TestList_Reserve(t *testing.T) {
+	l := &List[int]{}
+	l.Reserve(100)
+
+	chunks := l.Stats().ChunkSizes
+	for i := 0; i < 100; i++ {
+		l.PushBack(i)
+	}
+	require.Equal(t, chunks, l.Stats().ChunkSizes)
+
+	l.Reserve(50)
+	require.Equal(t, chunks, l.Stats().ChunkSizes)
+
+	l.Grow(10)
+	chunks = append(chunks, 10)
+	require.Equal(t, chunks, l.Stats().ChunkSizes)
+	require.Equal(t, 110, l.Stats().Capacity)
+
+	for i := 0; i < 10; i++ {
+		l.PushBack(i)
+	}
+	require.Equal(t, chunks, l.Stats().ChunkSizes)
+	require.Equal(t, 110, l.Len())
+}
+
+func TestList_SetGrowth(t *testing.T) {
+	l := NewPresized[int](10)
+	l.SetGrowth(containers.DoublingGrowth(15))
+
+	for i := 0; i < 100; i++ {
+		l.PushBack(i)
+	}
+
+	require.Equal(t, []int{10, 10, 15, 15, 15, 15, 15, 15}, l.Stats().ChunkSizes)
+	require.Equal(t, 100, l.Len())
+}
+
+func TestList_Validate(t *testing.T) {
+	var z List[int]
+	require.NoError(t, z.Validate())
+
+	l := NewSized[int](100, func(v int) int64 {
+		return int64(v)
+	}, DropOldest)
+	for i := 0; i < 20; i++ {
+		l.PushBack(i)
+	}
+
+	l.Get(5)
+	l.Remove(3)
+	l.Get(7)
+	l.Rotate(2)
+	require.NoError(t, l.Validate())
+
+	for _, tc := range []struct {
+		name    string
+		corrupt func(l *List[int])
+	}{
+		{
+			name: "broken link",
+			corrupt: func(l *List[int]) {
+				l.root.Next().Next().SetPrev(&l.root)
+			},
+		},
+		{
+			name: "wrong len",
+			corrupt: func(l *List[int]) {
+				l.len++
+			},
+		},
+		{
+			name: "wrong size",
+			corrupt: func(l *List[int]) {
+				l.size++
+			},
+		},
+		{
+			name: "stale cursor",
+			corrupt: func(l *List[int]) {
+				l.Get(2)
+				l.cur.i = 3
+			},
+		},
+		{
+			name: "free node",
+			corrupt: func(l *List[int]) {
+				n := l.root.Next()
+				l.pool.Push(n)
+				n.SetNext(l.root.Next().Next())
+				n.SetPrev(&l.root)
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := newList(1, 2, 3)
+			tc.corrupt(l)
+
+			require.ErrorIs(t, l.Validate(), ErrCorrupted)
+		})
+	}
+}
+
+func TestList_Dump(t *testing.T) {
+	l := newList(1, 2)
+
+	var buf bytes.Buffer
+	require.NoError(t, l.Dump(&buf))
+	require.Equal(t, `len=2 size=0
+root: prev=n1 next=n0
+n0: prev=root next=n1 val=1
+n1: prev=n0 next=root val=2
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, l.DumpDOT(&buf))
+	require.Contains(t, buf.String(), `digraph "list" {`)
+	require.Contains(t, buf.String(), `"n1" [label="2"];`)
+	require.Contains(t, buf.String(), `"n0" -> "n1";`)
+}
@@ -0,0 +1,64 @@
+//go:build !containers_debug
+
+package list
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIter_Modified(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		modify  func(l *List[int])
+		expVals []int
+		expErr  error
+	}{
+		{
+			name:    "read",
+			modify:  func(l *List[int]) { l.Get(3) },
+			expVals: []int{0, 1, 2, 3, 4},
+		},
+		{
+			name:    "pop",
+			modify:  func(l *List[int]) { l.PopFront() },
+			expVals: []int{0, 1},
+			expErr:  ErrModified,
+		},
+		{
+			name: "push",
+			modify: func(l *List[int]) {
+				l.PushBack(5)
+			},
+			expVals: []int{0, 1},
+			expErr:  ErrModified,
+		},
+		{
+			name:    "swap",
+			modify:  func(l *List[int]) { l.Swap(0, 4) },
+			expVals: []int{0, 1},
+			expErr:  ErrModified,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := newList(0, 1, 2, 3, 4)
+
+			var vals []int
+			it := l.Iter()
+			for it.Next() {
+				vals = append(vals, it.Val())
+				if it.Val() == 1 {
+					tc.modify(l)
+				}
+			}
+
+			require.Equal(t, tc.expVals, vals)
+			require.ErrorIs(t, it.Err(), tc.expErr)
+			require.False(t, it.Next())
+		})
+	}
+}
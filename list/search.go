@@ -0,0 +1,120 @@
+package list
+
+import (
+	"github.com/glebziz/containers/internal/node"
+)
+
+// IndexFunc returns the index of the first element satisfying pred or -1 if none do.
+// The complexity is O(n).
+func (l *List[T]) IndexFunc(pred func(v T) bool) int {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	i := 0
+	for n := l.root.Next(); n != nil && n != &l.root; n = n.Next() {
+		if pred(n.Val()) {
+			return i
+		}
+
+		i++
+	}
+
+	return -1
+}
+
+// LastIndexFunc returns the index of the last element satisfying pred or -1 if none do.
+// The complexity is O(n).
+func (l *List[T]) LastIndexFunc(pred func(v T) bool) int {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	i := l.len - 1
+	for n := l.root.Prev(); n != nil && n != &l.root; n = n.Prev() {
+		if pred(n.Val()) {
+			return i
+		}
+
+		i--
+	}
+
+	return -1
+}
+
+// ContainsFunc reports whether at least one element of the list satisfies pred.
+// The complexity is O(n).
+func (l *List[T]) ContainsFunc(pred func(v T) bool) bool {
+	return l.IndexFunc(pred) >= 0
+}
+
+// RemoveFunc removes all elements satisfying pred and returns the number of removed elements.
+// The complexity is O(n).
+func (l *List[T]) RemoveFunc(pred func(v T) bool) int {
+	l.m.Lock()
+	defer l.unlock()
+
+	return l.removeFunc(pred)
+}
+
+// RemoveFirstFunc removes the first element satisfying pred and reports whether it was removed.
+// The complexity is O(n).
+func (l *List[T]) RemoveFirstFunc(pred func(v T) bool) bool {
+	l.m.Lock()
+	defer l.unlock()
+
+	n := l.find(pred)
+	if n == nil {
+		return false
+	}
+
+	l.remove(n)
+	return true
+}
+
+// removeFunc removes all elements satisfying pred and returns the number of removed elements.
+func (l *List[T]) removeFunc(pred func(v T) bool) int {
+	removed := 0
+	for n := l.root.Next(); n != nil && n != &l.root; {
+		next := n.Next()
+		if pred(n.Val()) {
+			l.remove(n)
+			removed++
+		}
+
+		n = next
+	}
+
+	return removed
+}
+
+// find returns the first node satisfying pred or nil if none do.
+func (l *List[T]) find(pred func(v T) bool) *node.Node[T] {
+	for n := l.root.Next(); n != nil && n != &l.root; n = n.Next() {
+		if pred(n.Val()) {
+			return n
+		}
+	}
+
+	return nil
+}
+
+// Index returns the index of the first occurrence of v in the list or -1 if not present.
+// The complexity is O(n).
+func Index[T comparable](l *List[T], v T) int {
+	return l.IndexFunc(func(e T) bool {
+		return e == v
+	})
+}
+
+// Contains reports whether v is present in the list.
+// The complexity is O(n).
+func Contains[T comparable](l *List[T], v T) bool {
+	return Index(l, v) >= 0
+}
+
+// RemoveValue removes all occurrences of v from the list and returns the number of removed elements.
+// The complexity is O(n).
+func RemoveValue[T comparable](l *List[T], v T) int {
+	return l.RemoveFunc(func(e T) bool {
+		return e == v
+	})
+}
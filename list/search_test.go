@@ -0,0 +1,158 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func isEven(v int) bool {
+	return v%2 == 0
+}
+
+func TestList_IndexFunc(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		l        func() *List[int]
+		expInd   int
+		expLast  int
+		expFound bool
+	}{
+		{
+			name: "zero list",
+			l: func() *List[int] {
+				return &List[int]{}
+			},
+			expInd:  -1,
+			expLast: -1,
+		},
+		{
+			name: "not found",
+			l: func() *List[int] {
+				return newList(1, 3, 5)
+			},
+			expInd:  -1,
+			expLast: -1,
+		},
+		{
+			name: "found",
+			l: func() *List[int] {
+				return newList(1, 2, 3, 4, 5)
+			},
+			expInd:   1,
+			expLast:  3,
+			expFound: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := tc.l()
+			require.Equal(t, tc.expInd, l.IndexFunc(isEven))
+			require.Equal(t, tc.expLast, l.LastIndexFunc(isEven))
+			require.Equal(t, tc.expFound, l.ContainsFunc(isEven))
+		})
+	}
+}
+
+func TestList_RemoveFunc(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		l          func() *List[int]
+		expRemoved int
+		expVals    []int
+	}{
+		{
+			name: "zero list",
+			l: func() *List[int] {
+				return &List[int]{}
+			},
+		},
+		{
+			name: "nothing to remove",
+			l: func() *List[int] {
+				return newList(1, 3)
+			},
+			expVals: []int{1, 3},
+		},
+		{
+			name: "remove several",
+			l: func() *List[int] {
+				return newList(2, 1, 4, 3, 6)
+			},
+			expRemoved: 3,
+			expVals:    []int{1, 3},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := tc.l()
+			require.Equal(t, tc.expRemoved, l.RemoveFunc(isEven))
+			require.Equal(t, tc.expVals, values(l))
+			require.Equal(t, tc.expVals, rvalues(l))
+			require.Equal(t, len(tc.expVals), l.Len())
+		})
+	}
+}
+
+func TestList_RemoveFirstFunc(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		l          func() *List[int]
+		expRemoved bool
+		expVals    []int
+	}{
+		{
+			name: "zero list",
+			l: func() *List[int] {
+				return &List[int]{}
+			},
+		},
+		{
+			name: "nothing to remove",
+			l: func() *List[int] {
+				return newList(1, 3)
+			},
+			expVals: []int{1, 3},
+		},
+		{
+			name: "remove first",
+			l: func() *List[int] {
+				return newList(1, 2, 4)
+			},
+			expRemoved: true,
+			expVals:    []int{1, 4},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := tc.l()
+			require.Equal(t, tc.expRemoved, l.RemoveFirstFunc(isEven))
+			require.Equal(t, tc.expVals, values(l))
+			require.Equal(t, len(tc.expVals), l.Len())
+		})
+	}
+}
+
+func TestIndex(t *testing.T) {
+	l := newList(1, 2, 3, 2)
+
+	require.Equal(t, 1, Index(l, 2))
+	require.Equal(t, -1, Index(l, 10))
+	require.True(t, Contains(l, 3))
+	require.False(t, Contains(l, 10))
+}
+
+func TestRemoveValue(t *testing.T) {
+	l := newList(1, 2, 3, 2)
+
+	require.Equal(t, 2, RemoveValue(l, 2))
+	require.Equal(t, []int{1, 3}, values(l))
+	require.Zero(t, RemoveValue(l, 10))
+	require.Equal(t, 2, l.Len())
+}
@@ -0,0 +1,229 @@
+package list
+
+import (
+	"github.com/glebziz/containers/internal/iter"
+	"github.com/glebziz/containers/internal/node"
+)
+
+// Tx is a transaction over the list created by Update or View.
+// A transaction is valid only until its function returns.
+type Tx[T any] struct {
+	l        *List[T]
+	writable bool
+
+	undo []func()
+	free []*node.Node[T]
+}
+
+// Update calls fn with a write transaction holding the list locked, so the changes made by fn are atomic.
+// If fn returns an error or panics, every change is rolled back,
+// the inserted nodes are returned to the pool and the hooks are not called.
+// The function must use only tx and must not call the list methods.
+func (l *List[T]) Update(fn func(tx *Tx[T]) error) error {
+	l.m.Lock()
+	defer l.unlock()
+
+	tx := &Tx[T]{
+		l:        l,
+		writable: true,
+	}
+	l.tx = tx
+
+	committed := false
+	defer func() {
+		l.tx = nil
+		if !committed {
+			tx.rollback()
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	committed = true
+	tx.commit()
+
+	return nil
+}
+
+// View calls fn with a read-only transaction holding the list locked for reading,
+// so fn sees a consistent state of the list. The write methods of tx panic.
+// The function must use only tx and must not call the list methods.
+func (l *List[T]) View(fn func(tx *Tx[T])) {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	fn(&Tx[T]{l: l})
+}
+
+// Len returns the number of elements of the list.
+func (tx *Tx[T]) Len() int {
+	return tx.l.len
+}
+
+// Iter returns a list iterator with forward direction.
+func (tx *Tx[T]) Iter() *iter.Iter[T] {
+	return tx.l.Iter()
+}
+
+// RIter returns a list iterator with reverse direction.
+func (tx *Tx[T]) RIter() *iter.Iter[T] {
+	return tx.l.RIter()
+}
+
+// Front returns the value of the first element of the list or zero value if the list is empty.
+func (tx *Tx[T]) Front() T {
+	return tx.l.root.Next().Val()
+}
+
+// Back returns the value of the last element of the list or zero value if the list is empty.
+func (tx *Tx[T]) Back() T {
+	return tx.l.root.Prev().Val()
+}
+
+// Get returns the value of the i-th element of the list or zero value if the list is empty or len < i.
+// The complexity is O(n), the access close to the previously accessed index is O(1).
+func (tx *Tx[T]) Get(i int) T {
+	return tx.l.get(i).Val()
+}
+
+// PushFront inserts a new value at the front of the list as described in List.PushFront.
+func (tx *Tx[T]) PushFront(v T) {
+	_ = tx.TryPushFront(v)
+}
+
+// PushBack inserts a new value at the back of the list as described in List.PushBack.
+func (tx *Tx[T]) PushBack(v T) {
+	_ = tx.TryPushBack(v)
+}
+
+// PushAfter inserts a new value after the i-th element of the list as described in List.PushAfter.
+func (tx *Tx[T]) PushAfter(i int, v T) {
+	_ = tx.TryPushAfter(i, v)
+}
+
+// PushBefore inserts a new value before the i-th element of the list as described in List.PushBefore.
+func (tx *Tx[T]) PushBefore(i int, v T) {
+	_ = tx.TryPushBefore(i, v)
+}
+
+// TryPushFront inserts a new value at the front of the list as described in List.TryPushFront.
+// A full list with the Block policy returns ErrFull.
+func (tx *Tx[T]) TryPushFront(v T) error {
+	tx.check()
+	tx.l.lazyInit()
+	return tx.l.insert(v, &tx.l.root, true)
+}
+
+// TryPushBack inserts a new value at the back of the list as described in List.TryPushBack.
+// A full list with the Block policy returns ErrFull.
+func (tx *Tx[T]) TryPushBack(v T) error {
+	tx.check()
+	tx.l.lazyInit()
+	return tx.l.insert(v, tx.l.root.Prev(), false)
+}
+
+// TryPushAfter inserts a new value after the i-th element of the list as described in List.TryPushAfter.
+// A full list with the Block policy returns ErrFull.
+func (tx *Tx[T]) TryPushAfter(i int, v T) error {
+	tx.check()
+	return tx.l.insert(v, tx.l.get(i), false)
+}
+
+// TryPushBefore inserts a new value before the i-th element of the list as described in List.TryPushBefore.
+// A full list with the Block policy returns ErrFull.
+func (tx *Tx[T]) TryPushBefore(i int, v T) error {
+	tx.check()
+	return tx.l.insert(v, tx.l.get(i).Prev(), false)
+}
+
+// PopFront returns and removes the first element of the list if the list is not empty.
+func (tx *Tx[T]) PopFront() T {
+	tx.check()
+	return tx.l.pop(tx.l.root.Next())
+}
+
+// PopBack returns and removes the last element of the list if the list is not empty.
+func (tx *Tx[T]) PopBack() T {
+	tx.check()
+	return tx.l.pop(tx.l.root.Prev())
+}
+
+// Remove removes the i-th element of the list if the i is less than len.
+func (tx *Tx[T]) Remove(i int) {
+	tx.check()
+	tx.l.remove(tx.l.get(i))
+}
+
+// RemoveFunc removes all elements satisfying pred and returns the number of removed elements.
+// The complexity is O(n).
+func (tx *Tx[T]) RemoveFunc(pred func(v T) bool) int {
+	tx.check()
+	return tx.l.removeFunc(pred)
+}
+
+// Set replaces the value of the i-th element of the list as described in List.Set.
+func (tx *Tx[T]) Set(i int, v T) error {
+	tx.check()
+	return tx.l.set(i, v)
+}
+
+// Reverse reverses the order of the elements of the list in place.
+// The complexity is O(n).
+func (tx *Tx[T]) Reverse() {
+	tx.check()
+	tx.l.reverse()
+}
+
+// Rotate rotates the list by n positions as described in List.Rotate.
+func (tx *Tx[T]) Rotate(n int) {
+	tx.check()
+	tx.l.rotate(n)
+}
+
+// Swap swaps the i-th and the j-th elements of the list
+// if both indexes are less than len.
+// The complexity is O(n).
+func (tx *Tx[T]) Swap(i, j int) {
+	tx.check()
+	tx.l.swap(i, j)
+}
+
+// Move moves the element at index from so that it ends up at index to
+// if both indexes are less than len.
+// The complexity is O(n).
+func (tx *Tx[T]) Move(from, to int) {
+	tx.check()
+	tx.l.move(from, to)
+}
+
+// check panics if the transaction is read-only.
+func (tx *Tx[T]) check() {
+	if !tx.writable {
+		panic("list: write in a read-only transaction")
+	}
+}
+
+// commit returns the removed nodes to the pool.
+func (tx *Tx[T]) commit() {
+	for _, n := range tx.free {
+		tx.l.pool.Push(n)
+	}
+}
+
+// rollback undoes the changes in reverse order and discards the recorded events.
+// It must be called after the transaction is detached from the list, so the undoing is not recorded.
+func (tx *Tx[T]) rollback() {
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		tx.undo[i]()
+	}
+
+	tx.l.events = nil
+}
+
+// undo records the function undoing a change in the running transaction.
+// The callers check that a transaction is running first, so the function is not allocated otherwise.
+func (l *List[T]) undo(fn func()) {
+	l.tx.undo = append(l.tx.undo, fn)
+}
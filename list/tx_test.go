@@ -0,0 +1,178 @@
+package list
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errAbort = errors.New("abort")
+
+func TestList_Update(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		l       func() *List[int]
+		update  func(tx *Tx[int]) error
+		panics  bool
+		expErr  error
+		expVals []int
+	}{
+		{
+			name: "commit",
+			l:    New[int],
+			update: func(tx *Tx[int]) error {
+				require.Equal(t, 1, tx.RemoveFunc(func(v int) bool { return v == 2 }))
+				tx.PushBack(4)
+				tx.PushFront(0)
+				require.NoError(t, tx.Set(1, 10))
+				require.Equal(t, 4, tx.Len())
+				require.Equal(t, 0, tx.Front())
+				require.Equal(t, 4, tx.Back())
+				return nil
+			},
+			expVals: []int{0, 10, 3, 4},
+		},
+		{
+			name: "error",
+			l:    New[int],
+			update: func(tx *Tx[int]) error {
+				require.Equal(t, 1, tx.PopFront())
+				require.Equal(t, 3, tx.PopBack())
+				tx.PushBack(4)
+				tx.PushAfter(0, 5)
+				tx.PushBefore(0, 6)
+				require.NoError(t, tx.Set(1, 20))
+				tx.Remove(3)
+				tx.Reverse()
+				tx.Rotate(1)
+				tx.Swap(0, 2)
+				tx.Move(0, 1)
+				require.Equal(t, []int{5, 20, 6}, txValues(tx))
+				require.Equal(t, 1, tx.RemoveFunc(func(v int) bool { return v == 5 }))
+				require.Equal(t, 20, tx.Get(0))
+				return errAbort
+			},
+			expErr:  errAbort,
+			expVals: []int{1, 2, 3},
+		},
+		{
+			name: "panic",
+			l:    New[int],
+			update: func(tx *Tx[int]) error {
+				tx.Remove(1)
+				tx.PushFront(0)
+				panic("boom")
+			},
+			panics:  true,
+			expVals: []int{1, 2, 3},
+		},
+		{
+			name: "error with evictions",
+			l: func() *List[int] {
+				return NewBounded[int](3, DropOldest)
+			},
+			update: func(tx *Tx[int]) error {
+				for i := 4; i <= 8; i++ {
+					tx.PushBack(i)
+				}
+
+				tx.PushFront(0)
+				require.Equal(t, []int{0, 6, 7}, txValues(tx))
+				return errAbort
+			},
+			expErr:  errAbort,
+			expVals: []int{1, 2, 3},
+		},
+		{
+			name: "error with sized evictions",
+			l: func() *List[int] {
+				return NewSized[int](10, func(v int) int64 { return int64(v) }, DropOldest)
+			},
+			update: func(tx *Tx[int]) error {
+				tx.PushBack(5)
+				require.ErrorIs(t, tx.Set(0, 9), ErrFull)
+				require.NoError(t, tx.Set(0, 2))
+				require.ErrorIs(t, tx.TryPushBack(11), ErrTooLarge)
+				return errAbort
+			},
+			expErr:  errAbort,
+			expVals: []int{1, 2, 3},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := tc.l()
+			for i := 1; i <= 3; i++ {
+				l.PushBack(i)
+			}
+
+			stats := l.Stats()
+			size := l.Size()
+
+			var hooked int
+			l.OnInsert(func(int) { hooked++ })
+			l.OnRemove(func(int) { hooked++ })
+			l.OnUpdate(func(int, int) { hooked++ })
+			l.OnEvict(func(int) { hooked++ })
+
+			update := func() {
+				require.ErrorIs(t, l.Update(tc.update), tc.expErr)
+			}
+
+			if tc.panics {
+				require.Panics(t, update)
+			} else {
+				update()
+			}
+
+			require.Equal(t, tc.expVals, values(l))
+			require.Equal(t, tc.expVals, rvalues(l))
+			require.NoError(t, l.Validate())
+
+			if tc.expErr == nil && !tc.panics {
+				require.NotZero(t, hooked)
+				return
+			}
+
+			require.Zero(t, hooked)
+			require.Equal(t, size, l.Size())
+			require.Equal(t, stats.Live, l.Stats().Live)
+		})
+	}
+}
+
+func TestList_View(t *testing.T) {
+	t.Parallel()
+
+	l := newList(1, 2, 3)
+	l.View(func(tx *Tx[int]) {
+		require.Equal(t, 3, tx.Len())
+		require.Equal(t, 2, tx.Get(1))
+		require.Equal(t, []int{1, 2, 3}, txValues(tx))
+
+		var rvals []int
+		for it := tx.RIter(); it.Next(); {
+			rvals = append(rvals, it.Val())
+		}
+
+		require.Equal(t, []int{3, 2, 1}, rvals)
+		require.Panics(t, func() {
+			tx.PopFront()
+		})
+	})
+
+	require.Equal(t, []int{1, 2, 3}, values(l))
+}
+
+// txValues returns the values of the list of the transaction in forward order.
+func txValues(tx *Tx[int]) []int {
+	var vals []int
+	for it := tx.Iter(); it.Next(); {
+		vals = append(vals, it.Val())
+	}
+
+	return vals
+}
@@ -0,0 +1,60 @@
+package multimap
+
+import (
+	"testing"
+)
+
+func BenchmarkMultiMap_Add(b *testing.B) {
+	b.Run("multimap", func(b *testing.B) {
+		b.ReportAllocs()
+
+		m := New[int, int]()
+
+		for i := 0; i < b.N; i++ {
+			m.Add(i%1024, i)
+		}
+	})
+
+	b.Run("presized multimap", func(b *testing.B) {
+		b.ReportAllocs()
+
+		m := NewPresized[int, int](b.N)
+
+		for i := 0; i < b.N; i++ {
+			m.Add(i%1024, i)
+		}
+	})
+}
+
+func BenchmarkMultiMap_DeleteOne(b *testing.B) {
+	b.ReportAllocs()
+
+	m := NewPresized[int, int](b.N)
+
+	for i := 0; i < b.N; i++ {
+		m.Add(i%1024, i)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.DeleteOne(i % 1024)
+	}
+}
+
+func BenchmarkMultiMap_Iter(b *testing.B) {
+	b.ReportAllocs()
+
+	m := NewPresized[int, int](b.N)
+
+	for i := 0; i < b.N; i++ {
+		m.Add(i%1024, i)
+	}
+
+	sum := 0
+	b.ResetTimer()
+
+	for it := m.Iter(); it.Next(); {
+		sum += it.Val()
+	}
+}
@@ -0,0 +1,27 @@
+package multimap_test
+
+import (
+	"fmt"
+
+	"github.com/glebziz/containers/multimap"
+)
+
+func ExampleNew() {
+	m := multimap.New[string, string]()
+
+	m.Add("Accept", "text/html")
+	m.Add("Host", "example.com")
+	m.Add("Accept", "application/json")
+
+	for it := m.Iter(); it.Next(); {
+		fmt.Println(it.Key(), it.Val())
+	}
+
+	fmt.Println(m.Get("Accept"))
+
+	// Output:
+	// Accept text/html
+	// Host example.com
+	// Accept application/json
+	// [text/html application/json]
+}
@@ -0,0 +1,163 @@
+// Package multimap implements an ordered multimap with a double linked list and a node pool.
+// A key may be added many times and all key-value pairs keep their global insertion order.
+//
+// To iterate over a multimap (where m is a *MultiMap):
+//
+//	it := m.Iter()
+//	for it.Next() {
+//		// do something with it.Key() and it.Val()
+//	}
+package multimap
+
+import (
+	"sync"
+
+	"github.com/glebziz/containers/internal/iter"
+	"github.com/glebziz/containers/internal/node"
+)
+
+// MultiMap represents an ordered multimap.
+// The zero value for MultiMap is an empty multimap ready to use.
+type MultiMap[K comparable, V any] struct {
+	data map[K][]*node.Node[node.Entry[K, V]]
+	root node.Node[node.Entry[K, V]]
+	pool *node.Pool[node.Entry[K, V]]
+	len  int
+
+	m sync.RWMutex
+}
+
+// New returns an initialized multimap.
+func New[K comparable, V any]() *MultiMap[K, V] {
+	p := node.NewPool[node.Entry[K, V]]()
+
+	return &MultiMap[K, V]{
+		data: make(map[K][]*node.Node[node.Entry[K, V]], p.Cap()),
+		pool: p,
+	}
+}
+
+// NewPresized returns an initialized multimap with an allocated pool of nodes.
+func NewPresized[K comparable, V any](size int) *MultiMap[K, V] {
+	return &MultiMap[K, V]{
+		data: make(map[K][]*node.Node[node.Entry[K, V]], size),
+		pool: node.NewPoolPresized[node.Entry[K, V]](size),
+	}
+}
+
+// Len returns the number of key-value pairs of multimap.
+func (m *MultiMap[K, V]) Len() int {
+	return m.len
+}
+
+// KeyLen returns the number of distinct keys of multimap.
+func (m *MultiMap[K, V]) KeyLen() int {
+	return len(m.data)
+}
+
+// Iter returns an iterator over the key-value pairs of the multimap in insertion order.
+func (m *MultiMap[K, V]) Iter() *iter.MapIter[K, V] {
+	return iter.NewMap[K, V](&m.root, iter.ForwardDir)
+}
+
+// Add appends the key-value pair to the back of the multimap.
+// The complexity is O(1).
+func (m *MultiMap[K, V]) Add(key K, val V) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	if m.pool == nil {
+		m.pool = node.NewPool[node.Entry[K, V]]()
+		m.data = make(map[K][]*node.Node[node.Entry[K, V]], m.pool.Cap())
+	}
+
+	if m.root.Next() == nil {
+		m.root.SetNext(&m.root)
+		m.root.SetPrev(&m.root)
+	}
+
+	n := m.pool.Pop()
+	n.SetVal(node.Entry[K, V]{Key: key, Val: val})
+	m.root.Prev().Insert(n)
+	m.data[key] = append(m.data[key], n)
+	m.len++
+}
+
+// Get returns all values by key in insertion order or nil if the key is not found.
+// The complexity is O(k), where k is the number of values of the key.
+func (m *MultiMap[K, V]) Get(key K) []V {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	nodes := m.data[key]
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	vals := make([]V, 0, len(nodes))
+	for _, n := range nodes {
+		vals = append(vals, n.Val().Val)
+	}
+
+	return vals
+}
+
+// GetFirst returns the first added value by key.
+// The complexity is O(1).
+func (m *MultiMap[K, V]) GetFirst(key K) (val V, ok bool) {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	nodes := m.data[key]
+	if len(nodes) == 0 {
+		return val, false
+	}
+
+	return nodes[0].Val().Val, true
+}
+
+// DeleteAll removes all values by key and returns the number of removed values.
+// The complexity is O(k), where k is the number of values of the key.
+func (m *MultiMap[K, V]) DeleteAll(key K) int {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	nodes := m.data[key]
+	delete(m.data, key)
+
+	for _, n := range nodes {
+		m.remove(n)
+	}
+
+	return len(nodes)
+}
+
+// DeleteOne removes the first added value by key and reports whether it was removed.
+// The complexity is O(1).
+func (m *MultiMap[K, V]) DeleteOne(key K) bool {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	nodes := m.data[key]
+	if len(nodes) == 0 {
+		return false
+	}
+
+	m.remove(nodes[0])
+
+	nodes[0] = nil
+	if len(nodes) == 1 {
+		delete(m.data, key)
+	} else {
+		m.data[key] = nodes[1:]
+	}
+
+	return true
+}
+
+// remove removes n from the multimap, decrements len.
+func (m *MultiMap[K, V]) remove(n *node.Node[node.Entry[K, V]]) {
+	n.Remove()
+	m.pool.Push(n)
+	m.len--
+}
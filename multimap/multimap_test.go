@@ -0,0 +1,232 @@
+package multimap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type pair struct {
+	k int
+	v string
+}
+
+func TestMultiMap_Add(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		k        int
+		v        string
+		m        func() *MultiMap[int, string]
+		expPairs []pair
+		expVals  []string
+	}{
+		{
+			name: "zero multimap",
+			k:    1,
+			v:    "a",
+			m: func() *MultiMap[int, string] {
+				return &MultiMap[int, string]{}
+			},
+			expPairs: []pair{{1, "a"}},
+			expVals:  []string{"a"},
+		},
+		{
+			name: "new key",
+			k:    2,
+			v:    "b",
+			m: func() *MultiMap[int, string] {
+				return newMultiMap(pair{1, "a"})
+			},
+			expPairs: []pair{{1, "a"}, {2, "b"}},
+			expVals:  []string{"b"},
+		},
+		{
+			name: "existing key",
+			k:    1,
+			v:    "c",
+			m: func() *MultiMap[int, string] {
+				return newMultiMap(pair{1, "a"}, pair{2, "b"})
+			},
+			expPairs: []pair{{1, "a"}, {2, "b"}, {1, "c"}},
+			expVals:  []string{"a", "c"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := tc.m()
+			m.Add(tc.k, tc.v)
+			require.Equal(t, tc.expPairs, pairs(m))
+			require.Equal(t, len(tc.expPairs), m.Len())
+			require.Equal(t, tc.expVals, m.Get(tc.k))
+		})
+	}
+}
+
+func TestMultiMap_Get(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		k        int
+		m        func() *MultiMap[int, string]
+		expVals  []string
+		expFirst string
+		expOk    bool
+	}{
+		{
+			name: "zero multimap",
+			k:    1,
+			m: func() *MultiMap[int, string] {
+				return &MultiMap[int, string]{}
+			},
+		},
+		{
+			name: "key not found",
+			k:    10,
+			m: func() *MultiMap[int, string] {
+				return newMultiMap(pair{1, "a"})
+			},
+		},
+		{
+			name: "several values",
+			k:    1,
+			m: func() *MultiMap[int, string] {
+				return newMultiMap(pair{1, "a"}, pair{2, "b"}, pair{1, "c"})
+			},
+			expVals:  []string{"a", "c"},
+			expFirst: "a",
+			expOk:    true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := tc.m()
+			require.Equal(t, tc.expVals, m.Get(tc.k))
+
+			v, ok := m.GetFirst(tc.k)
+			require.Equal(t, tc.expOk, ok)
+			require.Equal(t, tc.expFirst, v)
+		})
+	}
+}
+
+func TestMultiMap_DeleteAll(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		k          int
+		m          func() *MultiMap[int, string]
+		expRemoved int
+		expPairs   []pair
+	}{
+		{
+			name: "zero multimap",
+			k:    1,
+			m: func() *MultiMap[int, string] {
+				return &MultiMap[int, string]{}
+			},
+		},
+		{
+			name: "key not found",
+			k:    10,
+			m: func() *MultiMap[int, string] {
+				return newMultiMap(pair{1, "a"})
+			},
+			expPairs: []pair{{1, "a"}},
+		},
+		{
+			name: "several values",
+			k:    1,
+			m: func() *MultiMap[int, string] {
+				return newMultiMap(pair{1, "a"}, pair{2, "b"}, pair{1, "c"})
+			},
+			expRemoved: 2,
+			expPairs:   []pair{{2, "b"}},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := tc.m()
+			require.Equal(t, tc.expRemoved, m.DeleteAll(tc.k))
+			require.Equal(t, tc.expPairs, pairs(m))
+			require.Equal(t, len(tc.expPairs), m.Len())
+			require.Nil(t, m.Get(tc.k))
+		})
+	}
+}
+
+func TestMultiMap_DeleteOne(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		k        int
+		m        func() *MultiMap[int, string]
+		expOk    bool
+		expPairs []pair
+		expVals  []string
+		expKeys  int
+	}{
+		{
+			name: "zero multimap",
+			k:    1,
+			m: func() *MultiMap[int, string] {
+				return &MultiMap[int, string]{}
+			},
+		},
+		{
+			name: "last value",
+			k:    1,
+			m: func() *MultiMap[int, string] {
+				return newMultiMap(pair{1, "a"}, pair{2, "b"})
+			},
+			expOk:    true,
+			expPairs: []pair{{2, "b"}},
+			expKeys:  1,
+		},
+		{
+			name: "several values",
+			k:    1,
+			m: func() *MultiMap[int, string] {
+				return newMultiMap(pair{1, "a"}, pair{2, "b"}, pair{1, "c"})
+			},
+			expOk:    true,
+			expPairs: []pair{{2, "b"}, {1, "c"}},
+			expVals:  []string{"c"},
+			expKeys:  2,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := tc.m()
+			require.Equal(t, tc.expOk, m.DeleteOne(tc.k))
+			require.Equal(t, tc.expPairs, pairs(m))
+			require.Equal(t, tc.expVals, m.Get(tc.k))
+			require.Equal(t, len(tc.expPairs), m.Len())
+			require.Equal(t, tc.expKeys, m.KeyLen())
+		})
+	}
+}
+
+// newMultiMap returns a multimap with the pairs.
+func newMultiMap(ps ...pair) *MultiMap[int, string] {
+	m := NewPresized[int, string](len(ps))
+	for _, p := range ps {
+		m.Add(p.k, p.v)
+	}
+
+	return m
+}
+
+// pairs returns the pairs of the multimap in order.
+func pairs(m *MultiMap[int, string]) []pair {
+	var ps []pair
+	for it := m.Iter(); it.Next(); {
+		ps = append(ps, pair{it.Key(), it.Val()})
+	}
+
+	return ps
+}
@@ -1,6 +1,8 @@
 package omap
 
 import (
+	"fmt"
+	"sync/atomic"
 	"testing"
 )
 
@@ -129,3 +131,109 @@ func BenchmarkOMap_Iter(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkOMap_StoreParallel(b *testing.B) {
+	b.Run("ordered map", func(b *testing.B) {
+		b.ReportAllocs()
+
+		m := New[int, int]()
+		var key atomic.Int64
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				k := int(key.Add(1))
+				m.Store(k, k)
+			}
+		})
+	})
+
+	for _, shards := range []int{4, 16, 64} {
+		shards := shards
+		b.Run(fmt.Sprintf("sharded ordered map %d", shards), func(b *testing.B) {
+			b.ReportAllocs()
+
+			m := NewSharded[int, int](shards, intHash)
+			var key atomic.Int64
+
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					k := int(key.Add(1))
+					m.Store(k, k)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkOMap_MixedParallel(b *testing.B) {
+	const (
+		keys = 1 << 16
+	)
+
+	b.Run("ordered map", func(b *testing.B) {
+		b.ReportAllocs()
+
+		m := NewPresized[int, int](keys)
+
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				k := i % keys
+				switch i % 4 {
+				case 0:
+					m.Delete(k)
+				case 1:
+					m.Store(k, i)
+				default:
+					m.Load(k)
+				}
+				i++
+			}
+		})
+	})
+
+	for _, shards := range []int{4, 16, 64} {
+		shards := shards
+		b.Run(fmt.Sprintf("sharded ordered map %d", shards), func(b *testing.B) {
+			b.ReportAllocs()
+
+			m := NewShardedPresized[int, int](shards, keys, intHash)
+
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					k := i % keys
+					switch i % 4 {
+					case 0:
+						m.Delete(k)
+					case 1:
+						m.Store(k, i)
+					default:
+						m.Load(k)
+					}
+					i++
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkOMap_StoreBounded(b *testing.B) {
+	const (
+		size = 1 << 10
+	)
+
+	b.ReportAllocs()
+
+	m := NewBounded[int, int](size, DropOldest)
+
+	for i := 0; i < size; i++ {
+		m.Store(i, i)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.Store(i, i)
+	}
+}
@@ -1,6 +1,8 @@
 package omap_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/glebziz/containers/omap"
@@ -19,3 +21,62 @@ func ExampleNew() {
 
 	// Output: Hello World !
 }
+
+func ExampleNewBounded() {
+	m := omap.NewBounded[string, bool](2, omap.DropOldest)
+
+	m.OnEvict(func(key string, _ bool) {
+		fmt.Println("evicted", key)
+	})
+
+	m.Store("a", true)
+	m.Store("b", true)
+	m.Store("c", true)
+
+	// Output: evicted a
+}
+
+func ExampleOMap_Subscribe() {
+	m := omap.New[string, int]()
+	m.Store("a", 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := m.Subscribe(ctx, 16, omap.WithSnapshot())
+
+	m.Store("b", 2)
+	m.Delete("a")
+
+	for i := 0; i < 3; i++ {
+		e := <-ch
+		fmt.Println(e.Kind, e.Key, e.Val, e.Snapshot)
+	}
+
+	// Output:
+	// store a 1 true
+	// store b 2 false
+	// delete a 1 false
+}
+
+func ExampleOMap_Update() {
+	m := omap.New[string, int]()
+	m.Store("a", 1)
+	m.Store("b", 2)
+
+	err := m.Update(func(tx *omap.Tx[string, int]) error {
+		tx.Delete("a")
+		tx.Store("c", 3)
+
+		return errors.New("abort")
+	})
+	fmt.Println(err)
+
+	for it := m.Iter(); it.Next(); {
+		fmt.Print(it.Key(), " ")
+	}
+
+	// Output:
+	// abort
+	// a b
+}
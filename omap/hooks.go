@@ -0,0 +1,122 @@
+package omap
+
+// eventKind is the kind of a change of the map reported to the hooks.
+type eventKind uint8
+
+const (
+	insertEvent eventKind = iota
+	removeEvent
+	updateEvent
+	evictEvent
+)
+
+// event is a change of the map recorded with the map locked
+// and reported to the hooks after the map is unlocked.
+type event[K comparable, V any] struct {
+	kind eventKind
+	key  K
+	old  V
+	val  V
+}
+
+// hooks are the functions observing the changes of the map.
+type hooks[K comparable, V any] struct {
+	onInsert func(key K, val V)
+	onRemove func(key K, val V)
+	onUpdate func(key K, old, val V)
+	onEvict  func(key K, val V)
+}
+
+// OnInsert sets the function called with every entry stored by a new key.
+//
+// The hooks are called after the map is unlocked in the goroutine that changed the map,
+// in the order of the changes made by the call, so they may call the map methods.
+// The hooks of concurrent calls may run concurrently.
+func (m *OMap[K, V]) OnInsert(fn func(key K, val V)) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.hooks.onInsert = fn
+}
+
+// OnRemove sets the function called with every entry removed by Delete.
+// The entries evicted because the map is full are reported to OnEvict instead.
+// The function is called as described in OnInsert.
+func (m *OMap[K, V]) OnRemove(fn func(key K, val V)) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.hooks.onRemove = fn
+}
+
+// OnUpdate sets the function called with the old and the new value of every entry stored by an existing key.
+// The function is called as described in OnInsert.
+func (m *OMap[K, V]) OnUpdate(fn func(key K, old, val V)) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.hooks.onUpdate = fn
+}
+
+// OnEvict sets the function called with every entry evicted because the bounded or sized map is full.
+// The function is called as described in OnInsert.
+func (m *OMap[K, V]) OnEvict(fn func(key K, val V)) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.hooks.onEvict = fn
+}
+
+// notify records the change if its hook is set.
+// It must be called with the map locked for writing.
+func (m *OMap[K, V]) notify(e event[K, V]) {
+	if m.hooks.has(e.kind) {
+		m.events = append(m.events, e)
+	}
+}
+
+// unlock unlocks the map, calls the hooks with the changes recorded while it was locked
+// and waits for the blocking subscribers to receive the changes.
+func (m *OMap[K, V]) unlock() {
+	events, h, blocked := m.events, m.hooks, m.blocked
+	m.events, m.blocked = nil, nil
+	m.m.Unlock()
+
+	for _, s := range blocked {
+		s.wait()
+	}
+
+	for _, e := range events {
+		h.call(e)
+	}
+}
+
+// has reports whether the hook of the kind is set.
+func (h *hooks[K, V]) has(kind eventKind) bool {
+	switch kind {
+	case insertEvent:
+		return h.onInsert != nil
+	case removeEvent:
+		return h.onRemove != nil
+	case updateEvent:
+		return h.onUpdate != nil
+	case evictEvent:
+		return h.onEvict != nil
+	}
+
+	return false
+}
+
+// call calls the hook of the event.
+func (h *hooks[K, V]) call(e event[K, V]) {
+	switch e.kind {
+	case insertEvent:
+		h.onInsert(e.key, e.val)
+	case removeEvent:
+		h.onRemove(e.key, e.val)
+	case updateEvent:
+		h.onUpdate(e.key, e.old, e.val)
+	case evictEvent:
+		h.onEvict(e.key, e.val)
+	}
+}
@@ -0,0 +1,133 @@
+package omap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOMap_Hooks(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		m         func() *OMap[int, int]
+		change    func(m *OMap[int, int])
+		expEvents []string
+		expKeys   []int
+	}{
+		{
+			name: "store new",
+			m:    New[int, int],
+			change: func(m *OMap[int, int]) {
+				m.Store(1, 10)
+				m.Store(2, 20)
+			},
+			expEvents: []string{"insert 1 10", "insert 2 20"},
+			expKeys:   []int{1, 2},
+		},
+		{
+			name: "store existing",
+			m:    New[int, int],
+			change: func(m *OMap[int, int]) {
+				m.Store(1, 10)
+				m.Store(2, 20)
+				m.Store(1, 11)
+			},
+			expEvents: []string{"insert 1 10", "insert 2 20", "update 1 10 11"},
+			expKeys:   []int{2, 1},
+		},
+		{
+			name: "delete",
+			m:    New[int, int],
+			change: func(m *OMap[int, int]) {
+				m.Store(1, 10)
+				m.Delete(1)
+				m.Delete(2)
+			},
+			expEvents: []string{"insert 1 10", "remove 1 10"},
+		},
+		{
+			name: "evict",
+			m: func() *OMap[int, int] {
+				return NewBounded[int, int](2, DropOldest)
+			},
+			change: func(m *OMap[int, int]) {
+				m.Store(1, 10)
+				m.Store(2, 20)
+				m.Store(3, 30)
+				m.Store(3, 31)
+			},
+			expEvents: []string{"insert 1 10", "insert 2 20", "evict 1 10", "insert 3 30", "update 3 30 31"},
+			expKeys:   []int{2, 3},
+		},
+		{
+			name: "too large",
+			m: func() *OMap[int, int] {
+				return NewSized[int, int](5, func(val int) int64 { return int64(val) }, DropOldest)
+			},
+			change: func(m *OMap[int, int]) {
+				m.Store(1, 6)
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := tc.m()
+
+			var events []string
+			m.OnInsert(func(key, val int) {
+				events = append(events, fmt.Sprint("insert ", key, " ", val))
+			})
+			m.OnRemove(func(key, val int) {
+				events = append(events, fmt.Sprint("remove ", key, " ", val))
+			})
+			m.OnUpdate(func(key, old, val int) {
+				events = append(events, fmt.Sprint("update ", key, " ", old, " ", val))
+			})
+			m.OnEvict(func(key, val int) {
+				events = append(events, fmt.Sprint("evict ", key, " ", val))
+			})
+
+			tc.change(m)
+			require.Equal(t, tc.expEvents, events)
+
+			var keys []int
+			for it := m.Iter(); it.Next(); {
+				keys = append(keys, it.Key())
+			}
+
+			require.Equal(t, tc.expKeys, keys)
+		})
+	}
+}
+
+func TestOMap_HooksReentrant(t *testing.T) {
+	t.Parallel()
+
+	m := NewBounded[int, int](2, DropOldest)
+
+	var evicted []int
+	m.OnEvict(func(key, _ int) {
+		_, ok := m.Load(key)
+		require.False(t, ok)
+
+		evicted = append(evicted, key)
+	})
+	m.OnRemove(func(key, val int) {
+		m.Store(key+10, val)
+	})
+
+	for i := 1; i <= 3; i++ {
+		m.Store(i, i)
+	}
+
+	m.Delete(2)
+
+	val, ok := m.Load(12)
+	require.True(t, ok)
+	require.Equal(t, 2, val)
+	require.Equal(t, []int{1}, evicted)
+	require.Equal(t, 2, m.Len())
+}
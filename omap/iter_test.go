@@ -24,6 +24,7 @@ func TestIter(t *testing.T) {
 	it = m.Iter()
 
 	for it.Next() {
+		require.Equal(t, i, it.Key())
 		require.Equal(t, i, it.Val())
 		i++
 	}
@@ -0,0 +1,63 @@
+//go:build !containers_debug
+
+package omap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIter_Modified(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 5; i++ {
+		m.Store(i, i)
+	}
+
+	var keys []int
+	it := m.Iter()
+	for it.Next() {
+		keys = append(keys, it.Key())
+		m.Load(it.Key())
+	}
+
+	require.Equal(t, []int{0, 1, 2, 3, 4}, keys)
+	require.NoError(t, it.Err())
+
+	keys = keys[:0]
+	it = m.Iter()
+	for it.Next() {
+		keys = append(keys, it.Key())
+		if it.Key() == 1 {
+			m.Store(0, 10)
+		}
+	}
+
+	require.Equal(t, []int{0, 1}, keys)
+	require.ErrorIs(t, it.Err(), ErrModified)
+
+	it = m.Iter()
+	m.Delete(3)
+	require.False(t, it.Next())
+	require.ErrorIs(t, it.Err(), ErrModified)
+}
+
+func TestOMap_UpdateIter(t *testing.T) {
+	t.Parallel()
+
+	m := New[int, int]()
+	for i := 1; i <= 3; i++ {
+		m.Store(i, i*10)
+	}
+
+	it := m.Iter()
+	require.True(t, it.Next())
+
+	require.ErrorIs(t, m.Update(func(tx *Tx[int, int]) error {
+		tx.Delete(2)
+		return errAbort
+	}), errAbort)
+
+	require.False(t, it.Next())
+	require.ErrorIs(t, it.Err(), ErrModified)
+}
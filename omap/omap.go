@@ -3,34 +3,85 @@
 // To iterate over a map (where m is a *OMap):
 //
 //	it := m.Iter()
-//	for m.Next() {
-//		// do something with it.Val()
+//	for it.Next() {
+//		// do something with it.Key() and it.Val()
 //	}
 package omap
 
 import (
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
 	"sync"
+	"sync/atomic"
 
+	"github.com/glebziz/containers"
 	"github.com/glebziz/containers/internal/iter"
 	"github.com/glebziz/containers/internal/node"
 )
 
+var (
+	// ErrFull is returned by TryStore when a value is stored into a full bounded or sized map with the Reject policy.
+	ErrFull = errors.New("omap: map is full")
+	// ErrTooLarge is returned by TryStore when the size of a stored value exceeds the size limit of the map.
+	ErrTooLarge = errors.New("omap: value is larger than the size limit")
+	// ErrCorrupted is returned by Validate when an invariant of the map is broken.
+	ErrCorrupted = errors.New("omap: corrupted structure")
+	// ErrModified is returned by the Err method of an iterator when the map was structurally modified during the iteration.
+	ErrModified = iter.ErrModified
+)
+
+// Policy is the overflow policy of a bounded or sized map.
+type Policy int
+
+const (
+	// DropOldest evicts the first (oldest) entries of the map to free space for the stored value.
+	DropOldest Policy = iota
+	// Reject discards the stored value and keeps the map unchanged, TryStore returns ErrFull.
+	Reject
+)
+
+// Stats is a snapshot of the pool of nodes of a map.
+// It implements expvar.Var by formatting itself as JSON.
+type Stats = node.PoolStats
+
 // OMap represents an ordered map.
 // The zero value for OMap is an empty map ready to use.
 type OMap[K comparable, V any] struct {
-	data map[K]*node.Node[V]
-	root node.Node[V]
-	pool *node.Pool[V]
+	data map[K]*node.Node[node.Entry[K, V]]
+	root node.Node[node.Entry[K, V]]
+	pool *node.Pool[node.Entry[K, V]]
+
+	capacity int
+	policy   Policy
+
+	hooks  hooks[K, V]
+	events []event[K, V]
+
+	subs    map[<-chan Event[K, V]]*subscription[K, V]
+	blocked []*subscription[K, V]
+	seq     uint64
+
+	// tx is the running write transaction.
+	tx *Tx[K, V]
+
+	sizeFn  func(val V) int64
+	maxSize int64
+	size    int64
+
+	// mod is the modification counter checked by the iterators.
+	mod atomic.Uint64
 
 	m sync.RWMutex
 }
 
 // New returns an initialized map.
 func New[K comparable, V any]() *OMap[K, V] {
-	p := node.NewPool[V]()
+	p := node.NewPool[node.Entry[K, V]]()
 
 	return &OMap[K, V]{
-		data: make(map[K]*node.Node[V], p.Cap()),
+		data: make(map[K]*node.Node[node.Entry[K, V]], p.Cap()),
 		pool: p,
 	}
 }
@@ -38,47 +89,162 @@ func New[K comparable, V any]() *OMap[K, V] {
 // NewPresized returns an initialized map with an allocated pool of nodes.
 func NewPresized[K comparable, V any](size int) *OMap[K, V] {
 	return &OMap[K, V]{
-		data: make(map[K]*node.Node[V], size),
-		pool: node.NewPoolPresized[V](size),
+		data: make(map[K]*node.Node[node.Entry[K, V]], size),
+		pool: node.NewPoolPresized[node.Entry[K, V]](size),
+	}
+}
+
+// NewWithPool returns an initialized map that takes its nodes from the pool shared with other maps.
+// The pool is not safe for concurrent use, so the maps sharing it must be used under a common lock.
+// The pool type is internal to the module, so only the containers of the module build maps on shared pools.
+func NewWithPool[K comparable, V any](pool *node.Pool[node.Entry[K, V]]) *OMap[K, V] {
+	return &OMap[K, V]{
+		data: make(map[K]*node.Node[node.Entry[K, V]]),
+		pool: pool,
 	}
 }
 
+// NewBounded returns an initialized map that holds at most size entries
+// with a pool of nodes allocated for exactly that many entries.
+// Storing a new key into a full map is handled according to the policy.
+// If the size is less than one, the map holds one entry.
+func NewBounded[K comparable, V any](size int, policy Policy) *OMap[K, V] {
+	if size < 1 {
+		size = 1
+	}
+
+	m := NewPresized[K, V](size)
+	m.capacity = size
+	m.policy = policy
+
+	return m
+}
+
+// NewSized returns an initialized map that keeps the total size of its values
+// computed by sizeFn within maxSize.
+// Storing a value that does not fit is handled according to the policy.
+// The sizeFn must return the same size for the same value during the whole time it is in the map.
+func NewSized[K comparable, V any](maxSize int64, sizeFn func(val V) int64, policy Policy) *OMap[K, V] {
+	m := New[K, V]()
+	m.sizeFn = sizeFn
+	m.maxSize = maxSize
+	m.policy = policy
+
+	return m
+}
+
+// SetZeroing sets whether the removed entries are zeroed in the pool of nodes.
+// By default, only the entries of types containing pointers are zeroed.
+// If *V implements the Reset method, it is called for every removed value regardless of this setting.
+// The Reset method of a pointer type V is not called, the caller still owns the pointed value.
+func (m *OMap[K, V]) SetZeroing(zero bool) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.lazyInit()
+	m.pool.SetZeroing(zero)
+}
+
+// SetGrowth sets the growth policy of the pool of nodes.
+// If the policy is nil, every new chunk of nodes doubles the capacity of the map.
+func (m *OMap[K, V]) SetGrowth(g containers.Growth) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.lazyInit()
+	m.pool.SetGrowth(g)
+}
+
+// Grow makes sure that n more entries can be stored without allocation of nodes.
+func (m *OMap[K, V]) Grow(n int) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.reserve(len(m.data) + n)
+}
+
+// Reserve makes sure that the map can hold n entries without allocation of nodes.
+// The index of an empty map is also allocated for n keys.
+func (m *OMap[K, V]) Reserve(n int) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.reserve(n)
+}
+
+// Cap returns the maximum number of entries of a bounded map or zero if the map is unbounded.
+func (m *OMap[K, V]) Cap() int {
+	return m.capacity
+}
+
+// Size returns the total size of the values of a sized map or zero if the map is not sized.
+func (m *OMap[K, V]) Size() int64 {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	return m.size
+}
+
+// Stats returns the stats of the pool of nodes of the map.
+func (m *OMap[K, V]) Stats() Stats {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	return m.pool.Stats()
+}
+
+// Var returns the expvar variable reporting the current stats of the map:
+//
+//	expvar.Publish("sessions", m.Var())
+func (m *OMap[K, V]) Var() expvar.Var {
+	return expvar.Func(func() any {
+		return m.Stats()
+	})
+}
+
 // Len returns the number of elements of map.
 func (m *OMap[K, V]) Len() int {
 	return len(m.data)
 }
 
 // Iter returns an iterator of the ordered map.
-func (m *OMap[K, V]) Iter() *iter.Iter[V] {
-	return iter.New[V](&m.root, iter.ForwardDir)
+// The iteration stops with ErrModified if the map is structurally modified.
+func (m *OMap[K, V]) Iter() *iter.MapIter[K, V] {
+	return iter.NewCheckedMap[K, V](&m.root, iter.ForwardDir, &m.mod)
 }
 
-// Store stores the value by key in the map.
-// The complexity is O(1).
+// Store stores the value by key at the back of the map.
+// If the bounded or sized map is full, its overflow policy is applied,
+// a rejected value is discarded silently.
+// The complexity is O(1) excluding evictions.
 func (m *OMap[K, V]) Store(key K, val V) {
+	_ = m.TryStore(key, val)
+}
+
+// TryStore stores the value by key at the back of the map like Store.
+// ErrFull is returned without changes if the bounded or sized map is full and its policy is Reject.
+// ErrTooLarge is returned without changes if the size of the value exceeds the size limit of the map.
+func (m *OMap[K, V]) TryStore(key K, val V) error {
 	m.m.Lock()
-	defer m.m.Unlock()
+	defer m.unlock()
 
-	n, ok := m.data[key]
-	if ok {
-		n.Remove()
-	} else {
-		if m.pool == nil {
-			m.pool = node.NewPool[V]()
-			m.data = make(map[K]*node.Node[V], m.pool.Cap())
-		}
+	return m.store(key, val)
+}
 
-		if m.root.Next() == nil {
-			m.root.SetNext(&m.root)
-			m.root.SetPrev(&m.root)
-		}
+// Replace replaces the value by key keeping the entry in its position and reports whether the key is present.
+// If the sized map is full, its overflow policy is applied to the other entries,
+// a rejected value is discarded silently.
+// The complexity is O(1) excluding evictions.
+func (m *OMap[K, V]) Replace(key K, val V) bool {
+	m.m.Lock()
+	defer m.unlock()
 
-		n = m.pool.Pop()
-		m.data[key] = n
+	_, ok := m.data[key]
+	if ok {
+		_ = m.replace(key, val)
 	}
 
-	n.SetVal(val)
-	m.root.Prev().Insert(n)
+	return ok
 }
 
 // Load returns the value by key from the map.
@@ -88,19 +254,309 @@ func (m *OMap[K, V]) Load(key K) (val V, ok bool) {
 	defer m.m.RUnlock()
 
 	n, ok := m.data[key]
-	return n.Val(), ok
+	return n.Val().Val, ok
+}
+
+// Front returns the first (oldest) entry of the map and reports whether the map is not empty.
+// The complexity is O(1).
+func (m *OMap[K, V]) Front() (key K, val V, ok bool) {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	return m.entry(m.root.Next())
+}
+
+// Back returns the last (newest) entry of the map and reports whether the map is not empty.
+// The complexity is O(1).
+func (m *OMap[K, V]) Back() (key K, val V, ok bool) {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	return m.entry(m.root.Prev())
 }
 
 // Delete removes the value by key from the map.
 // The complexity is O(1).
 func (m *OMap[K, V]) Delete(key K) {
 	m.m.Lock()
-	defer m.m.Unlock()
+	defer m.unlock()
+
+	m.delete(key)
+}
+
+// MoveToFront moves the entry by key to the front of the map and reports whether the key is present.
+// The complexity is O(1).
+func (m *OMap[K, V]) MoveToFront(key K) bool {
+	m.m.Lock()
+	defer m.unlock()
+
+	return m.move(key, true)
+}
+
+// MoveToBack moves the entry by key to the back of the map and reports whether the key is present.
+// The complexity is O(1).
+func (m *OMap[K, V]) MoveToBack(key K) bool {
+	m.m.Lock()
+	defer m.unlock()
+
+	return m.move(key, false)
+}
+
+// Validate checks the invariants of the map: the links of the nodes are symmetric,
+// the index and the order of the entries agree, no node of the map is free in the pool
+// and the size of a sized map is consistent.
+// The complexity is O(n).
+func (m *OMap[K, V]) Validate() error {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	var size int64
+	err := node.Validate(&m.root, len(m.data), m.pool, func(i int, n *node.Node[node.Entry[K, V]]) error {
+		e := n.Val()
+		if m.data[e.Key] != n {
+			return fmt.Errorf("node %d with key %v is not indexed", i, e.Key)
+		}
+
+		size += m.sizeOf(e.Val)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCorrupted, err)
+	}
+
+	switch {
+	case size != m.size:
+		return fmt.Errorf("%w: size is %d, expected %d", ErrCorrupted, m.size, size)
+	case m.capacity > 0 && len(m.data) > m.capacity:
+		return fmt.Errorf("%w: len %d exceeds capacity %d", ErrCorrupted, len(m.data), m.capacity)
+	}
+
+	return nil
+}
+
+// Dump writes the len of the map and its nodes with their links to w for debugging.
+// At most len+1 nodes are written, so a corrupted map does not loop forever.
+func (m *OMap[K, V]) Dump(w io.Writer) error {
+	m.m.RLock()
+	defer m.m.RUnlock()
 
+	if _, err := fmt.Fprintf(w, "len=%d size=%d\n", len(m.data), m.size); err != nil {
+		return err
+	}
+
+	return node.Dump(w, &m.root, len(m.data)+1, format[K, V])
+}
+
+// DumpDOT writes the nodes of the map with their links to w as a Graphviz digraph for debugging.
+func (m *OMap[K, V]) DumpDOT(w io.Writer) error {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	return node.DumpDOT(w, "omap", &m.root, len(m.data)+1, format[K, V])
+}
+
+// lazyInit lazily initializes a zero OMap value.
+func (m *OMap[K, V]) lazyInit() {
+	if m.pool == nil {
+		m.pool = node.NewPool[node.Entry[K, V]]()
+		m.data = make(map[K]*node.Node[node.Entry[K, V]], m.pool.Cap())
+	}
+
+	if m.root.Next() == nil {
+		m.root.SetNext(&m.root)
+		m.root.SetPrev(&m.root)
+	}
+}
+
+// reserve grows the pool of nodes to hold n entries.
+func (m *OMap[K, V]) reserve(n int) {
+	m.lazyInit()
+
+	if len(m.data) == 0 {
+		m.data = make(map[K]*node.Node[node.Entry[K, V]], n)
+	}
+
+	m.pool.Grow(n - len(m.data))
+}
+
+// store stores the value by key at the back of the map.
+// In a transaction the change is recorded to be undone on rollback.
+func (m *OMap[K, V]) store(key K, val V) error {
+	size := m.sizeOf(val)
+	if m.maxSize > 0 && size > m.maxSize {
+		return ErrTooLarge
+	}
+
+	var old node.Entry[K, V]
 	n, ok := m.data[key]
+	if m.policy == Reject && m.full(!ok, size-m.oldSize(n, ok)) {
+		return ErrFull
+	}
+
 	if ok {
-		delete(m.data, key)
+		old = n.Val()
+		m.undo(n, n.Prev(), old)
 		n.Remove()
-		m.pool.Push(n)
+		m.size -= m.sizeOf(old.Val)
+	} else {
+		m.lazyInit()
+	}
+
+	for m.full(!ok, size) {
+		m.evict(m.root.Next())
 	}
+
+	if !ok {
+		n = m.pool.Pop()
+		m.data[key] = n
+		m.undo(n, nil, old)
+	}
+
+	n.SetVal(node.Entry[K, V]{Key: key, Val: val})
+	m.root.Prev().Insert(n)
+	m.size += size
+	m.mod.Add(1)
+
+	m.publish(Event[K, V]{Kind: EventStore, Key: key, Val: val})
+	if ok {
+		m.notify(event[K, V]{kind: updateEvent, key: key, old: old.Val, val: val})
+	} else {
+		m.notify(event[K, V]{kind: insertEvent, key: key, val: val})
+	}
+
+	return nil
+}
+
+// replace replaces the value of the present key keeping the entry in its position.
+// The entries evicted to make room for the value are taken from the front skipping the replaced one.
+func (m *OMap[K, V]) replace(key K, val V) error {
+	size := m.sizeOf(val)
+	if m.maxSize > 0 && size > m.maxSize {
+		return ErrTooLarge
+	}
+
+	n := m.data[key]
+	old := n.Val()
+	if m.policy == Reject && m.full(false, size-m.sizeOf(old.Val)) {
+		return ErrFull
+	}
+
+	m.undo(n, n.Prev(), old)
+	m.size -= m.sizeOf(old.Val)
+
+	for m.full(false, size) {
+		victim := m.root.Next()
+		if victim == n {
+			victim = n.Next()
+		}
+
+		m.evict(victim)
+	}
+
+	n.SetVal(node.Entry[K, V]{Key: key, Val: val})
+	m.size += size
+	m.mod.Add(1)
+
+	m.publish(Event[K, V]{Kind: EventReplace, Key: key, Val: val})
+	m.notify(event[K, V]{kind: updateEvent, key: key, old: old.Val, val: val})
+
+	return nil
+}
+
+// delete removes the value by key from the map.
+func (m *OMap[K, V]) delete(key K) {
+	n, ok := m.data[key]
+	if ok {
+		m.notify(event[K, V]{kind: removeEvent, key: key, val: n.Val().Val})
+		m.publish(Event[K, V]{Kind: EventDelete, Key: key, Val: n.Val().Val})
+		m.remove(n)
+	}
+}
+
+// move moves the entry by key to the front or to the back of the map and reports whether the key is present.
+func (m *OMap[K, V]) move(key K, front bool) bool {
+	n, ok := m.data[key]
+	if !ok {
+		return false
+	}
+
+	m.undo(n, n.Prev(), n.Val())
+	n.Remove()
+	if front {
+		m.root.Insert(n)
+	} else {
+		m.root.Prev().Insert(n)
+	}
+
+	m.mod.Add(1)
+	m.publish(Event[K, V]{Kind: EventMove, Key: key, Val: n.Val().Val, Front: front})
+
+	return true
+}
+
+// evict removes n from the map and reports it to the hooks and the subscribers as evicted.
+func (m *OMap[K, V]) evict(n *node.Node[node.Entry[K, V]]) {
+	e := n.Val()
+	m.notify(event[K, V]{kind: evictEvent, key: e.Key, val: e.Val})
+	m.publish(Event[K, V]{Kind: EventDelete, Key: e.Key, Val: e.Val})
+	m.remove(n)
+}
+
+// remove removes n from the map.
+// In a transaction the node is kept out of the pool until the commit.
+func (m *OMap[K, V]) remove(n *node.Node[node.Entry[K, V]]) {
+	e := n.Val()
+	delete(m.data, e.Key)
+	m.size -= m.sizeOf(e.Val)
+	m.mod.Add(1)
+
+	if m.tx != nil {
+		m.undo(n, n.Prev(), e)
+		n.Remove()
+		m.tx.free = append(m.tx.free, n)
+		return
+	}
+
+	n.Remove()
+	m.pool.Push(n)
+}
+
+// full reports whether a value of the size does not fit into the map.
+// The number of entries is checked only for a new key.
+func (m *OMap[K, V]) full(newKey bool, size int64) bool {
+	return (newKey && m.capacity > 0 && len(m.data) >= m.capacity) ||
+		(m.maxSize > 0 && m.size+size > m.maxSize)
+}
+
+// entry returns the entry of the node or false if the node is the root of an empty map.
+func (m *OMap[K, V]) entry(n *node.Node[node.Entry[K, V]]) (key K, val V, ok bool) {
+	if n == nil || n == &m.root {
+		return key, val, false
+	}
+
+	e := n.Val()
+	return e.Key, e.Val, true
+}
+
+// oldSize returns the size of the value of the node if the key is present.
+func (m *OMap[K, V]) oldSize(n *node.Node[node.Entry[K, V]], ok bool) int64 {
+	if !ok {
+		return 0
+	}
+
+	return m.sizeOf(n.Val().Val)
+}
+
+// sizeOf returns the size of the value or zero if the map is not sized.
+func (m *OMap[K, V]) sizeOf(val V) int64 {
+	if m.sizeFn == nil {
+		return 0
+	}
+
+	return m.sizeFn(val)
+}
+
+// format returns the default format of the entry.
+func format[K comparable, V any](e node.Entry[K, V]) string {
+	return fmt.Sprintf("%v: %v", e.Key, e.Val)
 }
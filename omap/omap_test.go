@@ -1,10 +1,12 @@
 package omap
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/glebziz/containers"
 	"github.com/glebziz/containers/internal/node"
 )
 
@@ -25,7 +27,7 @@ func TestOMap_Store(t *testing.T) {
 			},
 			checkMap: func(t *testing.T, m *OMap[int, int]) {
 				require.Equal(t, 1, m.Len())
-				require.Equal(t, 10, m.root.Next().Val())
+				require.Equal(t, 10, m.root.Next().Val().Val)
 				require.Equal(t, m.root.Next(), m.data[20])
 				require.Equal(t, m.root.Next(), m.root.Prev())
 			},
@@ -36,9 +38,9 @@ func TestOMap_Store(t *testing.T) {
 			v:    10,
 			m: func() *OMap[int, int] {
 				m := NewPresized[int, int](1)
-				next := node.Node[int]{}
+				next := node.Node[node.Entry[int, int]]{}
 
-				next.SetVal(1)
+				next.SetVal(node.Entry[int, int]{Key: 2, Val: 1})
 				next.SetNext(&m.root)
 				next.SetPrev(&m.root)
 				m.root.SetNext(&next)
@@ -49,8 +51,8 @@ func TestOMap_Store(t *testing.T) {
 			},
 			checkMap: func(t *testing.T, m *OMap[int, int]) {
 				require.Equal(t, 2, m.Len())
-				require.Equal(t, 1, m.root.Next().Val())
-				require.Equal(t, 10, m.root.Prev().Val())
+				require.Equal(t, 1, m.root.Next().Val().Val)
+				require.Equal(t, 10, m.root.Prev().Val().Val)
 				require.Equal(t, m.root.Prev(), m.data[20])
 				require.Equal(t, m.root.Next().Next(), m.root.Prev())
 				require.Equal(t, m.root.Prev().Prev(), m.root.Next())
@@ -62,9 +64,9 @@ func TestOMap_Store(t *testing.T) {
 			v:    10,
 			m: func() *OMap[int, int] {
 				m := NewPresized[int, int](1)
-				next := node.Node[int]{}
+				next := node.Node[node.Entry[int, int]]{}
 
-				next.SetVal(1)
+				next.SetVal(node.Entry[int, int]{Key: 20, Val: 1})
 				next.SetNext(&m.root)
 				next.SetPrev(&m.root)
 				m.root.SetNext(&next)
@@ -75,7 +77,7 @@ func TestOMap_Store(t *testing.T) {
 			},
 			checkMap: func(t *testing.T, m *OMap[int, int]) {
 				require.Equal(t, 1, m.Len())
-				require.Equal(t, 10, m.root.Next().Val())
+				require.Equal(t, 10, m.root.Next().Val().Val)
 				require.Equal(t, m.root.Next(), m.data[20])
 				require.Equal(t, m.root.Next(), m.root.Prev())
 			},
@@ -92,6 +94,73 @@ func TestOMap_Store(t *testing.T) {
 	}
 }
 
+func TestOMap_FrontBack(t *testing.T) {
+	var z OMap[int, int]
+	_, _, ok := z.Front()
+	require.False(t, ok)
+	_, _, ok = z.Back()
+	require.False(t, ok)
+
+	m := New[int, int]()
+	m.Store(1, 10)
+	m.Store(2, 20)
+	m.Store(3, 30)
+	m.MoveToFront(2)
+
+	k, v, ok := m.Front()
+	require.True(t, ok)
+	require.Equal(t, pair{2, 20}, pair{k, v})
+
+	k, v, ok = m.Back()
+	require.True(t, ok)
+	require.Equal(t, pair{3, 30}, pair{k, v})
+
+	m.Delete(1)
+	m.Delete(2)
+	m.Delete(3)
+	_, _, ok = m.Front()
+	require.False(t, ok)
+}
+
+func TestOMap_Replace(t *testing.T) {
+	m := New[int, int]()
+	require.False(t, m.Replace(1, 10))
+	require.Zero(t, m.Len())
+
+	m.Store(1, 10)
+	m.Store(2, 20)
+	m.Store(3, 30)
+	require.True(t, m.Replace(1, 11))
+
+	k, v, ok := m.Front()
+	require.True(t, ok)
+	require.Equal(t, pair{1, 11}, pair{k, v})
+
+	s := NewSized[int, int](5, func(val int) int64 { return int64(val) }, DropOldest)
+	s.Store(1, 2)
+	s.Store(2, 3)
+
+	var evicted []pair
+	s.OnEvict(func(key, val int) {
+		evicted = append(evicted, pair{key, val})
+	})
+
+	// The replaced entry is at the front, so the next entry is evicted.
+	require.True(t, s.Replace(1, 4))
+	require.Equal(t, []pair{{2, 3}}, evicted)
+	require.Equal(t, int64(4), s.Size())
+	require.NoError(t, s.Validate())
+
+	r := NewSized[int, int](5, func(val int) int64 { return int64(val) }, Reject)
+	r.Store(1, 2)
+	r.Store(2, 3)
+	require.True(t, r.Replace(1, 4))
+
+	v, _ = r.Load(1)
+	require.Equal(t, 2, v)
+	require.Equal(t, int64(5), r.Size())
+}
+
 func TestOMap_Load(t *testing.T) {
 	for _, tc := range []struct {
 		name   string
@@ -114,13 +183,13 @@ func TestOMap_Load(t *testing.T) {
 			k:    20,
 			m: func() *OMap[int, int] {
 				m := New[int, int]()
-				first := node.Node[int]{}
-				second := node.Node[int]{}
-				third := node.Node[int]{}
+				first := node.Node[node.Entry[int, int]]{}
+				second := node.Node[node.Entry[int, int]]{}
+				third := node.Node[node.Entry[int, int]]{}
 
-				first.SetVal(1)
-				second.SetVal(10)
-				third.SetVal(100)
+				first.SetVal(node.Entry[int, int]{Key: 2, Val: 1})
+				second.SetVal(node.Entry[int, int]{Key: 20, Val: 10})
+				third.SetVal(node.Entry[int, int]{Key: 200, Val: 100})
 
 				m.root.SetNext(&first)
 				m.root.SetPrev(&third)
@@ -143,9 +212,9 @@ func TestOMap_Load(t *testing.T) {
 			k:    20,
 			m: func() *OMap[int, int] {
 				m := New[int, int]()
-				first := node.Node[int]{}
+				first := node.Node[node.Entry[int, int]]{}
 
-				first.SetVal(1)
+				first.SetVal(node.Entry[int, int]{Key: 2, Val: 1})
 
 				m.root.SetNext(&first)
 				m.root.SetPrev(&first)
@@ -197,13 +266,13 @@ func TestOMap_Delete(t *testing.T) {
 			k:    20,
 			m: func() *OMap[int, int] {
 				m := New[int, int]()
-				first := node.Node[int]{}
-				second := node.Node[int]{}
-				third := node.Node[int]{}
+				first := node.Node[node.Entry[int, int]]{}
+				second := node.Node[node.Entry[int, int]]{}
+				third := node.Node[node.Entry[int, int]]{}
 
-				first.SetVal(1)
-				second.SetVal(10)
-				third.SetVal(100)
+				first.SetVal(node.Entry[int, int]{Key: 2, Val: 1})
+				second.SetVal(node.Entry[int, int]{Key: 20, Val: 10})
+				third.SetVal(node.Entry[int, int]{Key: 200, Val: 100})
 
 				m.root.SetNext(&first)
 				m.root.SetPrev(&third)
@@ -220,7 +289,7 @@ func TestOMap_Delete(t *testing.T) {
 			},
 			checkMap: func(t *testing.T, m *OMap[int, int]) {
 				require.Equal(t, 2, m.Len())
-				require.Equal(t, 100, m.root.Next().Next().Val())
+				require.Equal(t, 100, m.root.Next().Next().Val().Val)
 			},
 		},
 		{
@@ -228,9 +297,9 @@ func TestOMap_Delete(t *testing.T) {
 			k:    20,
 			m: func() *OMap[int, int] {
 				m := New[int, int]()
-				first := node.Node[int]{}
+				first := node.Node[node.Entry[int, int]]{}
 
-				first.SetVal(1)
+				first.SetVal(node.Entry[int, int]{Key: 2, Val: 1})
 
 				m.root.SetNext(&first)
 				m.root.SetPrev(&first)
@@ -254,3 +323,396 @@ func TestOMap_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestNewBounded(t *testing.T) {
+	m := NewBounded[int, int](3, DropOldest)
+	require.Equal(t, 3, m.Cap())
+	require.Equal(t, 3, m.pool.Cap())
+
+	m = NewBounded[int, int](0, DropOldest)
+	require.Equal(t, 1, m.Cap())
+	require.Zero(t, New[int, int]().Cap())
+}
+
+func TestOMap_Bounded(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		policy     Policy
+		ops        func(m *OMap[int, int])
+		expPairs   []pair
+		expEvicted []pair
+	}{
+		{
+			name: "not full",
+			ops: func(m *OMap[int, int]) {
+				m.Store(1, 10)
+				m.Store(2, 20)
+			},
+			expPairs: []pair{{1, 10}, {2, 20}},
+		},
+		{
+			name: "oldest is evicted",
+			ops: func(m *OMap[int, int]) {
+				m.Store(1, 10)
+				m.Store(2, 20)
+				m.Store(3, 30)
+				m.Store(4, 40)
+				m.Store(5, 50)
+			},
+			expPairs:   []pair{{3, 30}, {4, 40}, {5, 50}},
+			expEvicted: []pair{{1, 10}, {2, 20}},
+		},
+		{
+			name: "existing key does not evict",
+			ops: func(m *OMap[int, int]) {
+				m.Store(1, 10)
+				m.Store(2, 20)
+				m.Store(3, 30)
+				m.Store(1, 11)
+				m.Store(4, 40)
+			},
+			expPairs:   []pair{{3, 30}, {1, 11}, {4, 40}},
+			expEvicted: []pair{{2, 20}},
+		},
+		{
+			name:   "reject new key",
+			policy: Reject,
+			ops: func(m *OMap[int, int]) {
+				m.Store(1, 10)
+				m.Store(2, 20)
+				m.Store(3, 30)
+				require.ErrorIs(t, m.TryStore(4, 40), ErrFull)
+				m.Store(5, 50)
+			},
+			expPairs: []pair{{1, 10}, {2, 20}, {3, 30}},
+		},
+		{
+			name:   "reject updates existing key",
+			policy: Reject,
+			ops: func(m *OMap[int, int]) {
+				m.Store(1, 10)
+				m.Store(2, 20)
+				m.Store(3, 30)
+				require.NoError(t, m.TryStore(1, 11))
+			},
+			expPairs: []pair{{2, 20}, {3, 30}, {1, 11}},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewBounded[int, int](3, tc.policy)
+
+			var evicted []pair
+			m.OnEvict(func(k, v int) {
+				evicted = append(evicted, pair{k, v})
+			})
+
+			tc.ops(m)
+			require.Equal(t, tc.expPairs, pairs(m))
+			require.Equal(t, tc.expEvicted, evicted)
+			require.Equal(t, len(tc.expPairs), m.Len())
+			require.Equal(t, 3, m.pool.Cap())
+		})
+	}
+}
+
+// pairs returns the pairs of the map in order.
+func pairs(m *OMap[int, int]) []pair {
+	var ps []pair
+	for it := m.Iter(); it.Next(); {
+		ps = append(ps, pair{it.Key(), it.Val()})
+	}
+
+	return ps
+}
+
+func TestOMap_Sized(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		policy     Policy
+		ops        func(m *OMap[int, int]) error
+		expErr     error
+		expPairs   []pair
+		expEvicted []pair
+		expSize    int64
+	}{
+		{
+			name: "fits",
+			ops: func(m *OMap[int, int]) error {
+				return m.TryStore(3, 1)
+			},
+			expPairs: []pair{{1, 2}, {2, 3}, {3, 1}},
+			expSize:  6,
+		},
+		{
+			name: "oldest entries are evicted",
+			ops: func(m *OMap[int, int]) error {
+				return m.TryStore(3, 5)
+			},
+			expPairs:   []pair{{3, 5}},
+			expEvicted: []pair{{1, 2}, {2, 3}},
+			expSize:    5,
+		},
+		{
+			name: "existing key is updated",
+			ops: func(m *OMap[int, int]) error {
+				return m.TryStore(1, 3)
+			},
+			expPairs: []pair{{2, 3}, {1, 3}},
+			expSize:  6,
+		},
+		{
+			name: "existing key evicts others",
+			ops: func(m *OMap[int, int]) error {
+				return m.TryStore(1, 4)
+			},
+			expPairs:   []pair{{1, 4}},
+			expEvicted: []pair{{2, 3}},
+			expSize:    4,
+		},
+		{
+			name: "too large",
+			ops: func(m *OMap[int, int]) error {
+				return m.TryStore(1, 7)
+			},
+			expErr:   ErrTooLarge,
+			expPairs: []pair{{1, 2}, {2, 3}},
+			expSize:  5,
+		},
+		{
+			name: "delete",
+			ops: func(m *OMap[int, int]) error {
+				m.Delete(2)
+				return nil
+			},
+			expPairs: []pair{{1, 2}},
+			expSize:  2,
+		},
+		{
+			name:   "reject",
+			policy: Reject,
+			ops: func(m *OMap[int, int]) error {
+				return m.TryStore(3, 5)
+			},
+			expErr:   ErrFull,
+			expPairs: []pair{{1, 2}, {2, 3}},
+			expSize:  5,
+		},
+		{
+			name:   "reject existing key that does not fit",
+			policy: Reject,
+			ops: func(m *OMap[int, int]) error {
+				return m.TryStore(1, 4)
+			},
+			expErr:   ErrFull,
+			expPairs: []pair{{1, 2}, {2, 3}},
+			expSize:  5,
+		},
+		{
+			name:   "reject keeps existing key that fits",
+			policy: Reject,
+			ops: func(m *OMap[int, int]) error {
+				return m.TryStore(1, 3)
+			},
+			expPairs: []pair{{2, 3}, {1, 3}},
+			expSize:  6,
+		},
+		{
+			name:   "reject without error",
+			policy: Reject,
+			ops: func(m *OMap[int, int]) error {
+				m.Store(3, 5)
+				return nil
+			},
+			expPairs: []pair{{1, 2}, {2, 3}},
+			expSize:  5,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewSized[int, int](6, func(v int) int64 {
+				return int64(v)
+			}, tc.policy)
+			m.Store(1, 2)
+			m.Store(2, 3)
+
+			var evicted []pair
+			m.OnEvict(func(k, v int) {
+				evicted = append(evicted, pair{k, v})
+			})
+
+			err := tc.ops(m)
+			require.ErrorIs(t, err, tc.expErr)
+			require.Equal(t, tc.expPairs, pairs(m))
+			require.Equal(t, tc.expEvicted, evicted)
+			require.Equal(t, tc.expSize, m.Size())
+		})
+	}
+}
+
+type resetVal struct {
+	resets *int
+}
+
+func (v *resetVal) Reset() {
+	*v.resets++
+}
+
+func TestOMap_ResetValues(t *testing.T) {
+	resets := 0
+
+	m := New[int, resetVal]()
+	m.Store(1, resetVal{resets: &resets})
+	m.Store(2, resetVal{resets: &resets})
+
+	m.Delete(1)
+	require.Equal(t, 1, resets)
+
+	m.Delete(3)
+	require.Equal(t, 1, resets)
+
+	b := NewBounded[int, resetVal](1, DropOldest)
+	b.Store(1, resetVal{resets: &resets})
+	b.Store(2, resetVal{resets: &resets})
+	require.Equal(t, 2, resets)
+}
+
+func TestOMap_Stats(t *testing.T) {
+	var z OMap[int, int]
+	require.Equal(t, Stats{}, z.Stats())
+
+	m := NewPresized[int, int](2)
+	for i := 0; i < 3; i++ {
+		m.Store(i, i)
+	}
+
+	m.Delete(0)
+	m.Store(1, 10)
+
+	s := m.Stats()
+	require.Equal(t, m.Len(), s.Live)
+	require.Equal(t, 1, s.Free)
+	require.Equal(t, 3, s.Allocated)
+	require.Equal(t, 4, s.Capacity)
+	require.Equal(t, 2, s.Chunks)
+	require.Equal(t, uint64(0), s.Hits)
+	require.Equal(t, uint64(3), s.Allocs)
+
+	require.JSONEq(t, s.String(), m.Var().String())
+}
+
+func TestOMap_Reserve(t *testing.T) {
+	m := &OMap[int, int]{}
+	m.Reserve(100)
+
+	chunks := m.Stats().ChunkSizes
+	for i := 0; i < 100; i++ {
+		m.Store(i, i)
+	}
+	require.Equal(t, chunks, m.Stats().ChunkSizes)
+
+	m.Grow(10)
+	chunks = append(chunks, 10)
+	require.Equal(t, 110, m.Stats().Capacity)
+
+	for i := 100; i < 110; i++ {
+		m.Store(i, i)
+	}
+	require.Equal(t, chunks, m.Stats().ChunkSizes)
+	require.Equal(t, 110, m.Len())
+}
+
+func TestOMap_SetGrowth(t *testing.T) {
+	m := NewPresized[int, int](10)
+	m.SetGrowth(containers.FixedGrowth(30))
+
+	for i := 0; i < 100; i++ {
+		m.Store(i, i)
+	}
+
+	require.Equal(t, []int{10, 30, 30, 30}, m.Stats().ChunkSizes)
+}
+
+func TestOMap_Validate(t *testing.T) {
+	var z OMap[int, int]
+	require.NoError(t, z.Validate())
+
+	m := NewSized[int, int](100, func(v int) int64 {
+		return int64(v)
+	}, DropOldest)
+	for i := 0; i < 20; i++ {
+		m.Store(i, i)
+	}
+
+	m.Delete(3)
+	m.Store(5, 50)
+	require.NoError(t, m.Validate())
+
+	for _, tc := range []struct {
+		name    string
+		corrupt func(m *OMap[int, int])
+	}{
+		{
+			name: "broken link",
+			corrupt: func(m *OMap[int, int]) {
+				m.root.Prev().SetNext(m.root.Next())
+			},
+		},
+		{
+			name: "missing key",
+			corrupt: func(m *OMap[int, int]) {
+				n := m.pool.Pop()
+				m.data[4] = n
+				delete(m.data, 1)
+			},
+		},
+		{
+			name: "unlinked key",
+			corrupt: func(m *OMap[int, int]) {
+				m.data[4] = m.pool.Pop()
+			},
+		},
+		{
+			name: "wrong size",
+			corrupt: func(m *OMap[int, int]) {
+				m.size = 1
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := New[int, int]()
+			for i := 1; i <= 3; i++ {
+				m.Store(i, i)
+			}
+			tc.corrupt(m)
+
+			require.ErrorIs(t, m.Validate(), ErrCorrupted)
+		})
+	}
+}
+
+func TestOMap_Dump(t *testing.T) {
+	m := New[string, int]()
+	m.Store("a", 1)
+	m.Store("b", 2)
+
+	var buf bytes.Buffer
+	require.NoError(t, m.Dump(&buf))
+	require.Equal(t, `len=2 size=0
+root: prev=n1 next=n0
+n0: prev=root next=n1 val=a: 1
+n1: prev=n0 next=root val=b: 2
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, m.DumpDOT(&buf))
+	require.Contains(t, buf.String(), `digraph "omap" {`)
+	require.Contains(t, buf.String(), `"n0" [label="a: 1"];`)
+}
@@ -0,0 +1,248 @@
+package omap
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/glebziz/containers/internal/node"
+)
+
+// Sharded represents an ordered map with keys partitioned across independently locked shards.
+// A global sequence number is assigned to every stored value,
+// so the iteration over all shards yields the global insertion order.
+// The zero value for Sharded is not ready to use, use NewSharded instead.
+type Sharded[K comparable, V any] struct {
+	shards []*shard[K, V]
+	hash   func(key K) uint64
+	seq    atomic.Uint64
+}
+
+// seqVal is a value with its global sequence number.
+type seqVal[V any] struct {
+	seq uint64
+	val V
+}
+
+// Reset resets the value if the pointer to the value implements node.Resetter.
+// It allows the pool to reset the values of the sharded map.
+func (v *seqVal[V]) Reset() {
+	if r, ok := any(&v.val).(node.Resetter); ok {
+		r.Reset()
+	}
+}
+
+// shard is an independently locked part of the sharded map.
+type shard[K comparable, V any] struct {
+	data map[K]*node.Node[node.Entry[K, seqVal[V]]]
+	root node.Node[node.Entry[K, seqVal[V]]]
+	pool *node.Pool[node.Entry[K, seqVal[V]]]
+
+	m sync.RWMutex
+}
+
+// NewSharded returns an initialized sharded map with n shards
+// and the hash function used to select the shard of a key.
+// If n is less than one, one shard is used.
+// NewSharded panics if hash is nil.
+func NewSharded[K comparable, V any](n int, hash func(key K) uint64) *Sharded[K, V] {
+	return NewShardedPresized[K, V](n, 0, hash)
+}
+
+// NewShardedPresized returns an initialized sharded map with n shards
+// and the pool of nodes allocated for size values split between the shards.
+func NewShardedPresized[K comparable, V any](n, size int, hash func(key K) uint64) *Sharded[K, V] {
+	if hash == nil {
+		panic("omap: nil hash function of a sharded map")
+	}
+
+	if n < 1 {
+		n = 1
+	}
+
+	m := &Sharded[K, V]{
+		shards: make([]*shard[K, V], n),
+		hash:   hash,
+	}
+
+	for i := range m.shards {
+		var p *node.Pool[node.Entry[K, seqVal[V]]]
+		if size > 0 {
+			p = node.NewPoolPresized[node.Entry[K, seqVal[V]]](size/n + 1)
+		} else {
+			p = node.NewPool[node.Entry[K, seqVal[V]]]()
+		}
+
+		s := &shard[K, V]{
+			data: make(map[K]*node.Node[node.Entry[K, seqVal[V]]], p.Cap()),
+			pool: p,
+		}
+		s.root.SetNext(&s.root)
+		s.root.SetPrev(&s.root)
+
+		m.shards[i] = s
+	}
+
+	return m
+}
+
+// Len returns the number of elements of map.
+func (m *Sharded[K, V]) Len() int {
+	l := 0
+	for _, s := range m.shards {
+		s.m.RLock()
+		l += len(s.data)
+		s.m.RUnlock()
+	}
+
+	return l
+}
+
+// Iter returns an iterator of the sharded map in the global insertion order.
+// Like the iterator of OMap it does not lock the shards.
+func (m *Sharded[K, V]) Iter() *ShardedIter[K, V] {
+	it := &ShardedIter[K, V]{
+		shards: m.shards,
+		cur:    make([]*node.Node[node.Entry[K, seqVal[V]]], len(m.shards)),
+	}
+
+	for i, s := range m.shards {
+		it.cur[i] = &s.root
+	}
+
+	return it
+}
+
+// Range calls fn for every key and value in the global insertion order until fn returns false.
+// The entries are copied while all shards are read locked, so fn sees a consistent state.
+// The shards are unlocked before the calls, so fn may use the map, but its changes are not seen by Range.
+// The complexity is O(n*s), where s is the number of shards.
+func (m *Sharded[K, V]) Range(fn func(key K, val V) bool) {
+	for _, e := range m.snapshot() {
+		if !fn(e.Key, e.Val) {
+			return
+		}
+	}
+}
+
+// snapshot returns the entries of the map in the global insertion order.
+func (m *Sharded[K, V]) snapshot() []node.Entry[K, V] {
+	for _, s := range m.shards {
+		s.m.RLock()
+		defer s.m.RUnlock()
+	}
+
+	l := 0
+	for _, s := range m.shards {
+		l += len(s.data)
+	}
+
+	entries := make([]node.Entry[K, V], 0, l)
+	for it := m.Iter(); it.Next(); {
+		entries = append(entries, node.Entry[K, V]{Key: it.Key(), Val: it.Val()})
+	}
+
+	return entries
+}
+
+// Store stores the value by key in the map.
+// The complexity is O(1).
+func (m *Sharded[K, V]) Store(key K, val V) {
+	s := m.shard(key)
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	n, ok := s.data[key]
+	if ok {
+		n.Remove()
+	} else {
+		n = s.pool.Pop()
+		s.data[key] = n
+	}
+
+	// The sequence number is taken under the shard lock,
+	// so the values of every shard are ordered by it.
+	n.SetVal(node.Entry[K, seqVal[V]]{
+		Key: key,
+		Val: seqVal[V]{
+			seq: m.seq.Add(1),
+			val: val,
+		},
+	})
+	s.root.Prev().Insert(n)
+}
+
+// Load returns the value by key from the map.
+// The complexity is O(1).
+func (m *Sharded[K, V]) Load(key K) (val V, ok bool) {
+	s := m.shard(key)
+
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	n, ok := s.data[key]
+	return n.Val().Val.val, ok
+}
+
+// Delete removes the value by key from the map.
+// The complexity is O(1).
+func (m *Sharded[K, V]) Delete(key K) {
+	s := m.shard(key)
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	n, ok := s.data[key]
+	if ok {
+		delete(s.data, key)
+		n.Remove()
+		s.pool.Push(n)
+	}
+}
+
+// shard returns the shard of the key.
+func (m *Sharded[K, V]) shard(key K) *shard[K, V] {
+	return m.shards[m.hash(key)%uint64(len(m.shards))]
+}
+
+// ShardedIter is an iterator that merges the shards of a sharded map by the sequence number.
+type ShardedIter[K comparable, V any] struct {
+	shards []*shard[K, V]
+	cur    []*node.Node[node.Entry[K, seqVal[V]]]
+	c      *node.Node[node.Entry[K, seqVal[V]]]
+}
+
+// Next selects the node with the least sequence number among the shards and returns true if it exists.
+// Otherwise, it returns false.
+// The complexity is O(s), where s is the number of shards.
+func (i *ShardedIter[K, V]) Next() bool {
+	least := -1
+	for j, s := range i.shards {
+		next := i.cur[j].Next()
+		if next == nil || next == &s.root {
+			continue
+		}
+
+		if least < 0 || next.Val().Val.seq < i.cur[least].Next().Val().Val.seq {
+			least = j
+		}
+	}
+
+	if least < 0 {
+		return false
+	}
+
+	i.cur[least] = i.cur[least].Next()
+	i.c = i.cur[least]
+	return true
+}
+
+// Key returns the key of the current node.
+func (i *ShardedIter[K, V]) Key() K {
+	return i.c.Val().Key
+}
+
+// Val returns the value of the current node.
+func (i *ShardedIter[K, V]) Val() V {
+	return i.c.Val().Val.val
+}
@@ -0,0 +1,199 @@
+package omap
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func intHash(key int) uint64 {
+	return uint64(key)
+}
+
+type pair struct {
+	k int
+	v int
+}
+
+func TestSharded_Store(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		shards   int
+		ops      func(m *Sharded[int, int])
+		expPairs []pair
+	}{
+		{
+			name:   "empty map",
+			shards: 4,
+			ops:    func(m *Sharded[int, int]) {},
+		},
+		{
+			name:   "global order across shards",
+			shards: 4,
+			ops: func(m *Sharded[int, int]) {
+				m.Store(3, 30)
+				m.Store(0, 0)
+				m.Store(5, 50)
+				m.Store(2, 20)
+			},
+			expPairs: []pair{{3, 30}, {0, 0}, {5, 50}, {2, 20}},
+		},
+		{
+			name:   "existing key moves to back",
+			shards: 4,
+			ops: func(m *Sharded[int, int]) {
+				m.Store(1, 10)
+				m.Store(2, 20)
+				m.Store(1, 11)
+			},
+			expPairs: []pair{{2, 20}, {1, 11}},
+		},
+		{
+			name:   "invalid number of shards",
+			shards: 0,
+			ops: func(m *Sharded[int, int]) {
+				m.Store(1, 10)
+				m.Store(2, 20)
+			},
+			expPairs: []pair{{1, 10}, {2, 20}},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewSharded[int, int](tc.shards, intHash)
+			tc.ops(m)
+			require.Equal(t, tc.expPairs, shardedPairs(m))
+			require.Equal(t, len(tc.expPairs), m.Len())
+		})
+	}
+}
+
+func TestSharded_Load(t *testing.T) {
+	m := NewShardedPresized[int, int](4, 10, intHash)
+	m.Store(1, 10)
+	m.Store(6, 60)
+
+	v, ok := m.Load(6)
+	require.True(t, ok)
+	require.Equal(t, 60, v)
+
+	v, ok = m.Load(2)
+	require.False(t, ok)
+	require.Zero(t, v)
+}
+
+func TestSharded_Delete(t *testing.T) {
+	m := NewSharded[int, int](4, intHash)
+	m.Store(1, 10)
+	m.Store(2, 20)
+	m.Store(5, 50)
+
+	m.Delete(1)
+	m.Delete(10)
+
+	require.Equal(t, []pair{{2, 20}, {5, 50}}, shardedPairs(m))
+	require.Equal(t, 2, m.Len())
+}
+
+func TestSharded_ResetValues(t *testing.T) {
+	m := NewSharded[int, resetVal](4, intHash)
+
+	resets := 0
+	m.Store(1, resetVal{resets: &resets})
+	m.Store(2, resetVal{resets: &resets})
+
+	m.Delete(1)
+	require.Equal(t, 1, resets)
+
+	m.Delete(3)
+	require.Equal(t, 1, resets)
+}
+
+func TestSharded_Range(t *testing.T) {
+	m := NewSharded[int, int](4, intHash)
+	for i := 0; i < 10; i++ {
+		m.Store(9-i, i)
+	}
+
+	var keys []int
+	m.Range(func(k, v int) bool {
+		keys = append(keys, k)
+		return len(keys) < 3
+	})
+
+	require.Equal(t, []int{9, 8, 7}, keys)
+
+	// The function may change the map while Range is running.
+	keys = keys[:0]
+	m.Range(func(k, v int) bool {
+		m.Store(k, v+1)
+		if got, ok := m.Load(k); ok {
+			keys = append(keys, got)
+		}
+
+		return true
+	})
+
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, keys)
+	require.Equal(t, 10, m.Len())
+}
+
+func TestNewSharded(t *testing.T) {
+	require.PanicsWithValue(t, "omap: nil hash function of a sharded map", func() {
+		NewSharded[int, int](4, nil)
+	})
+
+	m := NewSharded[int, int](0, intHash)
+	m.Store(1, 10)
+	require.Equal(t, 1, m.Len())
+}
+
+func TestSharded_Concurrent(t *testing.T) {
+	const (
+		G = 8
+		N = 1000
+	)
+
+	m := NewSharded[int, int](4, intHash)
+
+	var wg sync.WaitGroup
+	for g := 0; g < G; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+
+			for i := 0; i < N; i++ {
+				m.Store(g*N+i, i)
+			}
+		}(g)
+	}
+
+	wg.Wait()
+
+	require.Equal(t, G*N, m.Len())
+
+	last := make([]int, G)
+	for i := range last {
+		last[i] = -1
+	}
+
+	m.Range(func(k, v int) bool {
+		g := k / N
+		require.Greater(t, v, last[g])
+		last[g] = v
+		return true
+	})
+}
+
+// shardedPairs returns the pairs of the sharded map in order.
+func shardedPairs(m *Sharded[int, int]) []pair {
+	var ps []pair
+	for it := m.Iter(); it.Next(); {
+		ps = append(ps, pair{it.Key(), it.Val()})
+	}
+
+	return ps
+}
@@ -0,0 +1,305 @@
+package omap
+
+import (
+	"context"
+	"sync"
+)
+
+// EventKind is the kind of a change of the map delivered to the subscribers.
+type EventKind int
+
+const (
+	// EventStore is a value stored by a new or an existing key, the entry is at the back of the map.
+	EventStore EventKind = iota
+	// EventDelete is an entry removed by Delete or evicted because the map is full.
+	EventDelete
+	// EventMove is an entry moved to the front or to the back of the map.
+	EventMove
+	// EventReplace is a value replaced by an existing key, the entry keeps its position in the map.
+	EventReplace
+)
+
+// String returns the name of the event kind.
+func (k EventKind) String() string {
+	switch k {
+	case EventStore:
+		return "store"
+	case EventDelete:
+		return "delete"
+	case EventMove:
+		return "move"
+	case EventReplace:
+		return "replace"
+	}
+
+	return "unknown"
+}
+
+// Event is a change of the map delivered to the subscribers.
+type Event[K comparable, V any] struct {
+	Kind EventKind
+	Key  K
+	// Val is the stored, the removed, the moved or the replaced value.
+	Val V
+	// Front reports whether the entry of EventMove was moved to the front, otherwise it was moved to the back.
+	Front bool
+	// Snapshot reports whether the event is a part of the initial snapshot of the map.
+	Snapshot bool
+	// Seq is the sequence number of the change, the changes are numbered from one without gaps,
+	// so a gap between the events of a subscriber shows the dropped events.
+	// The snapshot events have the sequence number of the last change before the subscription.
+	Seq uint64
+}
+
+// SlowPolicy is the policy applied when a subscriber does not keep up with the changes of the map.
+type SlowPolicy int
+
+const (
+	// Drop drops the events that do not fit into the buffer of the subscriber.
+	Drop SlowPolicy = iota
+	// Block makes the writers wait until the buffer of the subscriber has room.
+	// The writer waits after the map is unlocked, so the subscriber may read the map,
+	// but it must not change the map from the goroutine receiving the events.
+	Block
+	// Disconnect closes the channel of the subscriber whose buffer is full.
+	Disconnect
+)
+
+// SubscribeOption configures a subscription.
+type SubscribeOption func(o *subscribeOptions)
+
+// subscribeOptions are the configurable parameters of a subscription.
+type subscribeOptions struct {
+	snapshot bool
+	policy   SlowPolicy
+}
+
+// WithSnapshot makes the subscription start with an EventStore for every entry of the map in order.
+// The snapshot is delivered regardless of the buffer size.
+func WithSnapshot() SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.snapshot = true
+	}
+}
+
+// WithSlowPolicy sets the policy applied when the subscriber does not keep up, Drop by default.
+func WithSlowPolicy(p SlowPolicy) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.policy = p
+	}
+}
+
+// Subscribe returns a channel delivering the changes of the map in the order they were made.
+// Up to bufSize events are buffered for a slow subscriber, then the slow policy is applied.
+// The channel is closed when ctx is done, on Unsubscribe or when the subscriber is disconnected.
+func (m *OMap[K, V]) Subscribe(ctx context.Context, bufSize int, opts ...SubscribeOption) <-chan Event[K, V] {
+	var o subscribeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	s := &subscription[K, V]{
+		out:    make(chan Event[K, V]),
+		wake:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+		size:   max(bufSize, 1),
+		policy: o.policy,
+	}
+	s.cond = sync.NewCond(&s.m)
+
+	m.m.Lock()
+	if o.snapshot {
+		for n := m.root.Next(); n != nil && n != &m.root; n = n.Next() {
+			e := n.Val()
+			s.snapshot = append(s.snapshot, Event[K, V]{
+				Kind:     EventStore,
+				Key:      e.Key,
+				Val:      e.Val,
+				Snapshot: true,
+				Seq:      m.seq,
+			})
+		}
+	}
+
+	if m.subs == nil {
+		m.subs = make(map[<-chan Event[K, V]]*subscription[K, V])
+	}
+	m.subs[s.out] = s
+	m.m.Unlock()
+
+	go s.run(ctx, m)
+
+	return s.out
+}
+
+// Unsubscribe stops the delivery of the changes to the channel returned by Subscribe and closes it.
+// The events buffered for the channel are discarded.
+func (m *OMap[K, V]) Unsubscribe(ch <-chan Event[K, V]) {
+	m.m.Lock()
+	s, ok := m.subs[ch]
+	delete(m.subs, ch)
+	m.m.Unlock()
+
+	if ok {
+		s.close()
+	}
+}
+
+// publish numbers the change and queues it for every subscriber.
+// The changes made in a transaction are published on its commit.
+// It must be called with the map locked for writing.
+func (m *OMap[K, V]) publish(e Event[K, V]) {
+	if m.tx != nil {
+		m.tx.events = append(m.tx.events, e)
+		return
+	}
+
+	m.seq++
+	e.Seq = m.seq
+
+	for ch, s := range m.subs {
+		switch s.push(e) {
+		case pushClosed:
+			delete(m.subs, ch)
+		case pushBlocked:
+			m.blocked = append(m.blocked, s)
+		}
+	}
+}
+
+// pushResult is the result of queueing an event for a subscriber.
+type pushResult int
+
+const (
+	pushDone pushResult = iota
+	pushBlocked
+	pushClosed
+)
+
+// subscription is a subscriber of the map with the queue of its undelivered events.
+// The events are queued with the map locked, so the queue is in the order of the changes,
+// and are sent to the channel by the goroutine of the subscription.
+type subscription[K comparable, V any] struct {
+	out    chan Event[K, V]
+	wake   chan struct{}
+	done   chan struct{}
+	size   int
+	policy SlowPolicy
+
+	m        sync.Mutex
+	cond     *sync.Cond
+	snapshot []Event[K, V]
+	queue    []Event[K, V]
+	closed   bool
+	once     sync.Once
+}
+
+// push queues the event according to the slow policy.
+func (s *subscription[K, V]) push(e Event[K, V]) pushResult {
+	s.m.Lock()
+	if s.closed {
+		s.m.Unlock()
+		return pushClosed
+	}
+
+	res := pushDone
+	if len(s.queue) >= s.size {
+		switch s.policy {
+		case Drop:
+			s.m.Unlock()
+			return pushDone
+		case Disconnect:
+			s.m.Unlock()
+			s.close()
+			return pushClosed
+		case Block:
+			res = pushBlocked
+		}
+	}
+
+	s.queue = append(s.queue, e)
+	s.m.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return res
+}
+
+// pop returns the first undelivered event, the snapshot goes first.
+func (s *subscription[K, V]) pop() (e Event[K, V], ok bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	switch {
+	case s.closed:
+		return e, false
+	case len(s.snapshot) > 0:
+		e = s.snapshot[0]
+		s.snapshot[0] = Event[K, V]{}
+		s.snapshot = s.snapshot[1:]
+	case len(s.queue) > 0:
+		e = s.queue[0]
+		s.queue[0] = Event[K, V]{}
+		s.queue = s.queue[1:]
+		s.cond.Broadcast()
+	default:
+		return e, false
+	}
+
+	return e, true
+}
+
+// wait waits until the queue fits into the buffer or the subscription is closed.
+func (s *subscription[K, V]) wait() {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	for !s.closed && len(s.queue) > s.size {
+		s.cond.Wait()
+	}
+}
+
+// close closes the subscription and discards the undelivered events.
+func (s *subscription[K, V]) close() {
+	s.once.Do(func() {
+		s.m.Lock()
+		s.closed = true
+		s.snapshot, s.queue = nil, nil
+		s.cond.Broadcast()
+		s.m.Unlock()
+
+		close(s.done)
+	})
+}
+
+// run sends the queued events to the channel until the subscription is closed or ctx is done.
+func (s *subscription[K, V]) run(ctx context.Context, m *OMap[K, V]) {
+	defer close(s.out)
+
+	for {
+		e, ok := s.pop()
+		if !ok {
+			select {
+			case <-s.wake:
+				continue
+			case <-s.done:
+				return
+			case <-ctx.Done():
+				m.Unsubscribe(s.out)
+				return
+			}
+		}
+
+		select {
+		case s.out <- e:
+		case <-s.done:
+			return
+		case <-ctx.Done():
+			m.Unsubscribe(s.out)
+			return
+		}
+	}
+}
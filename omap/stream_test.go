@@ -0,0 +1,257 @@
+package omap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOMap_Subscribe(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		m         func() *OMap[int, int]
+		opts      []SubscribeOption
+		change    func(m *OMap[int, int])
+		expEvents []Event[int, int]
+	}{
+		{
+			name: "store",
+			m:    New[int, int],
+			change: func(m *OMap[int, int]) {
+				m.Store(1, 10)
+				m.Store(2, 20)
+				m.Store(1, 11)
+			},
+			expEvents: []Event[int, int]{
+				{Kind: EventStore, Key: 1, Val: 10, Seq: 1},
+				{Kind: EventStore, Key: 2, Val: 20, Seq: 2},
+				{Kind: EventStore, Key: 1, Val: 11, Seq: 3},
+			},
+		},
+		{
+			name: "delete and move",
+			m:    New[int, int],
+			change: func(m *OMap[int, int]) {
+				m.Store(1, 10)
+				m.Store(2, 20)
+				m.MoveToFront(2)
+				m.MoveToBack(2)
+				m.MoveToFront(3)
+				m.Delete(1)
+				m.Delete(3)
+			},
+			expEvents: []Event[int, int]{
+				{Kind: EventStore, Key: 1, Val: 10, Seq: 1},
+				{Kind: EventStore, Key: 2, Val: 20, Seq: 2},
+				{Kind: EventMove, Key: 2, Val: 20, Front: true, Seq: 3},
+				{Kind: EventMove, Key: 2, Val: 20, Seq: 4},
+				{Kind: EventDelete, Key: 1, Val: 10, Seq: 5},
+			},
+		},
+		{
+			name: "evict",
+			m: func() *OMap[int, int] {
+				return NewBounded[int, int](1, DropOldest)
+			},
+			change: func(m *OMap[int, int]) {
+				m.Store(1, 10)
+				m.Store(2, 20)
+			},
+			expEvents: []Event[int, int]{
+				{Kind: EventStore, Key: 1, Val: 10, Seq: 1},
+				{Kind: EventDelete, Key: 1, Val: 10, Seq: 2},
+				{Kind: EventStore, Key: 2, Val: 20, Seq: 3},
+			},
+		},
+		{
+			name: "snapshot",
+			m: func() *OMap[int, int] {
+				m := New[int, int]()
+				m.Store(1, 10)
+				m.Store(2, 20)
+				m.Store(3, 30)
+				m.Delete(2)
+				return m
+			},
+			opts: []SubscribeOption{WithSnapshot()},
+			change: func(m *OMap[int, int]) {
+				m.Store(4, 40)
+			},
+			expEvents: []Event[int, int]{
+				{Kind: EventStore, Key: 1, Val: 10, Snapshot: true, Seq: 4},
+				{Kind: EventStore, Key: 3, Val: 30, Snapshot: true, Seq: 4},
+				{Kind: EventStore, Key: 4, Val: 40, Seq: 5},
+			},
+		},
+		{
+			name: "empty snapshot",
+			m:    func() *OMap[int, int] { return &OMap[int, int]{} },
+			opts: []SubscribeOption{WithSnapshot()},
+			change: func(m *OMap[int, int]) {
+				m.Store(1, 10)
+			},
+			expEvents: []Event[int, int]{
+				{Kind: EventStore, Key: 1, Val: 10, Seq: 1},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := tc.m()
+			ch := m.Subscribe(context.Background(), 16, tc.opts...)
+
+			tc.change(m)
+			require.Equal(t, tc.expEvents, receive(t, ch, len(tc.expEvents)))
+
+			m.Unsubscribe(ch)
+			requireClosed(t, ch)
+		})
+	}
+}
+
+func TestOMap_SubscribeDrop(t *testing.T) {
+	t.Parallel()
+
+	m := New[int, int]()
+	ch := m.Subscribe(context.Background(), 2, WithSlowPolicy(Drop))
+
+	for i := 1; i <= 10; i++ {
+		m.Store(i, i)
+	}
+
+	var events []Event[int, int]
+	for drained := false; !drained; {
+		select {
+		case e := <-ch:
+			events = append(events, e)
+		case <-time.After(50 * time.Millisecond):
+			drained = true
+		}
+	}
+
+	require.GreaterOrEqual(t, len(events), 2)
+	require.Less(t, len(events), 10)
+	for i := 1; i < len(events); i++ {
+		require.Less(t, events[i-1].Seq, events[i].Seq)
+	}
+
+	m.Store(0, 0)
+	require.Equal(t, []Event[int, int]{
+		{Kind: EventStore, Key: 0, Val: 0, Seq: 11},
+	}, receive(t, ch, 1))
+
+	m.Unsubscribe(ch)
+	requireClosed(t, ch)
+}
+
+func TestOMap_SubscribeBlock(t *testing.T) {
+	t.Parallel()
+
+	const n = 100
+
+	m := New[int, int]()
+	ch := m.Subscribe(context.Background(), 1, WithSlowPolicy(Block))
+
+	go func() {
+		for i := 0; i < n; i++ {
+			m.Store(i, i)
+		}
+	}()
+
+	for i := 0; i < n; i++ {
+		e := receive(t, ch, 1)[0]
+		require.Equal(t, uint64(i+1), e.Seq)
+		require.Equal(t, i, e.Key)
+
+		_, ok := m.Load(i)
+		require.True(t, ok)
+	}
+
+	m.Unsubscribe(ch)
+	requireClosed(t, ch)
+}
+
+func TestOMap_SubscribeDisconnect(t *testing.T) {
+	t.Parallel()
+
+	m := New[int, int]()
+	ch := m.Subscribe(context.Background(), 1, WithSlowPolicy(Disconnect))
+
+	for i := 0; i < 10; i++ {
+		m.Store(i, i)
+	}
+
+	require.Eventually(t, func() bool {
+		select {
+		case _, ok := <-ch:
+			return !ok
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+	require.Equal(t, 0, subscribers(m))
+}
+
+func TestOMap_SubscribeContext(t *testing.T) {
+	t.Parallel()
+
+	m := New[int, int]()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := m.Subscribe(ctx, 1, WithSlowPolicy(Block))
+	require.Equal(t, 1, subscribers(m))
+
+	cancel()
+	requireClosed(t, ch)
+	require.Equal(t, 0, subscribers(m))
+
+	for i := 0; i < 10; i++ {
+		m.Store(i, i)
+	}
+}
+
+// receive returns n events from the channel and fails the test if they are not delivered in time.
+func receive(t *testing.T, ch <-chan Event[int, int], n int) []Event[int, int] {
+	t.Helper()
+
+	var events []Event[int, int]
+	for len(events) < n {
+		select {
+		case e, ok := <-ch:
+			require.True(t, ok, "channel is closed")
+			events = append(events, e)
+		case <-time.After(time.Second):
+			require.FailNow(t, "event is not delivered")
+		}
+	}
+
+	return events
+}
+
+// requireClosed checks that the channel is closed after the undelivered events.
+func requireClosed(t *testing.T, ch <-chan Event[int, int]) {
+	t.Helper()
+
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-time.After(time.Second):
+			require.FailNow(t, "channel is not closed")
+		}
+	}
+}
+
+// subscribers returns the number of subscribers of the map.
+func subscribers(m *OMap[int, int]) int {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	return len(m.subs)
+}
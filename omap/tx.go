@@ -0,0 +1,176 @@
+package omap
+
+import (
+	"github.com/glebziz/containers/internal/iter"
+	"github.com/glebziz/containers/internal/node"
+)
+
+// Tx is a transaction over the map created by Update or View.
+// A transaction is valid only until its function returns.
+type Tx[K comparable, V any] struct {
+	m        *OMap[K, V]
+	writable bool
+
+	undo   []undoRecord[K, V]
+	free   []*node.Node[node.Entry[K, V]]
+	events []Event[K, V]
+}
+
+// undoRecord is the state of the node before a change made in a transaction.
+// The node without the previous node was inserted by the transaction,
+// otherwise it was linked after prev with the entry old.
+type undoRecord[K comparable, V any] struct {
+	n    *node.Node[node.Entry[K, V]]
+	prev *node.Node[node.Entry[K, V]]
+	old  node.Entry[K, V]
+}
+
+// Update calls fn with a write transaction holding the map locked, so the changes made by fn are atomic.
+// If fn returns an error or panics, every change is rolled back,
+// the inserted nodes are returned to the pool and the hooks and the subscribers are not notified.
+// The function must use only tx and must not call the map methods.
+func (m *OMap[K, V]) Update(fn func(tx *Tx[K, V]) error) error {
+	m.m.Lock()
+	defer m.unlock()
+
+	tx := &Tx[K, V]{
+		m:        m,
+		writable: true,
+	}
+	m.tx = tx
+
+	committed := false
+	defer func() {
+		m.tx = nil
+		if !committed {
+			tx.rollback()
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	committed = true
+	m.tx = nil
+	tx.commit()
+
+	return nil
+}
+
+// View calls fn with a read-only transaction holding the map locked for reading,
+// so fn sees a consistent state of the map. The write methods of tx panic.
+// The function must use only tx and must not call the map methods.
+func (m *OMap[K, V]) View(fn func(tx *Tx[K, V])) {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	fn(&Tx[K, V]{m: m})
+}
+
+// Len returns the number of elements of the map.
+func (tx *Tx[K, V]) Len() int {
+	return len(tx.m.data)
+}
+
+// Load returns the value by key from the map.
+// The complexity is O(1).
+func (tx *Tx[K, V]) Load(key K) (val V, ok bool) {
+	n, ok := tx.m.data[key]
+	return n.Val().Val, ok
+}
+
+// Iter returns a map iterator with forward direction.
+func (tx *Tx[K, V]) Iter() *iter.MapIter[K, V] {
+	return tx.m.Iter()
+}
+
+// Store stores the value by key at the back of the map as described in OMap.Store.
+func (tx *Tx[K, V]) Store(key K, val V) {
+	_ = tx.TryStore(key, val)
+}
+
+// TryStore stores the value by key at the back of the map as described in OMap.TryStore.
+func (tx *Tx[K, V]) TryStore(key K, val V) error {
+	tx.check()
+	return tx.m.store(key, val)
+}
+
+// Delete removes the value by key from the map.
+// The complexity is O(1).
+func (tx *Tx[K, V]) Delete(key K) {
+	tx.check()
+	tx.m.delete(key)
+}
+
+// MoveToFront moves the entry by key to the front of the map and reports whether the key is present.
+// The complexity is O(1).
+func (tx *Tx[K, V]) MoveToFront(key K) bool {
+	tx.check()
+	return tx.m.move(key, true)
+}
+
+// MoveToBack moves the entry by key to the back of the map and reports whether the key is present.
+// The complexity is O(1).
+func (tx *Tx[K, V]) MoveToBack(key K) bool {
+	tx.check()
+	return tx.m.move(key, false)
+}
+
+// check panics if the transaction is read-only.
+func (tx *Tx[K, V]) check() {
+	if !tx.writable {
+		panic("omap: write in a read-only transaction")
+	}
+}
+
+// commit returns the removed nodes to the pool and delivers the events to the subscribers.
+func (tx *Tx[K, V]) commit() {
+	m := tx.m
+	for _, n := range tx.free {
+		m.pool.Push(n)
+	}
+
+	for _, e := range tx.events {
+		m.publish(e)
+	}
+}
+
+// rollback undoes the changes in reverse order and discards the recorded events.
+func (tx *Tx[K, V]) rollback() {
+	m := tx.m
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		u := tx.undo[i]
+		if u.prev == nil {
+			e := u.n.Val()
+			delete(m.data, e.Key)
+			m.size -= m.sizeOf(e.Val)
+			u.n.Remove()
+			m.pool.Push(u.n)
+			continue
+		}
+
+		if m.data[u.old.Key] == u.n {
+			m.size -= m.sizeOf(u.n.Val().Val)
+			u.n.Remove()
+		}
+
+		u.n.SetVal(u.old)
+		u.prev.Insert(u.n)
+		m.data[u.old.Key] = u.n
+		m.size += m.sizeOf(u.old.Val)
+	}
+
+	if len(tx.undo) > 0 {
+		m.mod.Add(1)
+	}
+
+	m.events = nil
+}
+
+// undo records the state of the node before a change if a transaction is running.
+func (m *OMap[K, V]) undo(n, prev *node.Node[node.Entry[K, V]], old node.Entry[K, V]) {
+	if m.tx != nil {
+		m.tx.undo = append(m.tx.undo, undoRecord[K, V]{n: n, prev: prev, old: old})
+	}
+}
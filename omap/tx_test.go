@@ -0,0 +1,207 @@
+package omap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errAbort = errors.New("abort")
+
+func TestOMap_Update(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		m          func() *OMap[int, int]
+		update     func(tx *Tx[int, int]) error
+		panics     bool
+		expErr     error
+		expEntries []string
+	}{
+		{
+			name: "commit",
+			m:    New[int, int],
+			update: func(tx *Tx[int, int]) error {
+				tx.Delete(1)
+				tx.Delete(3)
+				tx.Store(4, 40)
+				tx.Store(2, 21)
+				require.True(t, tx.MoveToFront(4))
+				return nil
+			},
+			expEntries: []string{"4:40", "2:21"},
+		},
+		{
+			name: "read own writes",
+			m:    New[int, int],
+			update: func(tx *Tx[int, int]) error {
+				tx.Store(4, 40)
+				tx.Delete(1)
+
+				val, ok := tx.Load(4)
+				require.True(t, ok)
+				require.Equal(t, 40, val)
+
+				_, ok = tx.Load(1)
+				require.False(t, ok)
+				require.Equal(t, 3, tx.Len())
+				return nil
+			},
+			expEntries: []string{"2:20", "3:30", "4:40"},
+		},
+		{
+			name: "error",
+			m:    New[int, int],
+			update: func(tx *Tx[int, int]) error {
+				tx.Delete(1)
+				tx.Delete(3)
+				tx.Store(4, 40)
+				tx.Store(2, 21)
+				require.True(t, tx.MoveToFront(4))
+				require.True(t, tx.MoveToBack(2))
+				tx.Delete(4)
+				tx.Store(1, 11)
+				return errAbort
+			},
+			expErr:     errAbort,
+			expEntries: []string{"1:10", "2:20", "3:30"},
+		},
+		{
+			name: "panic",
+			m:    New[int, int],
+			update: func(tx *Tx[int, int]) error {
+				tx.Delete(2)
+				tx.Store(5, 50)
+				panic("boom")
+			},
+			panics:     true,
+			expEntries: []string{"1:10", "2:20", "3:30"},
+		},
+		{
+			name: "error with evictions",
+			m: func() *OMap[int, int] {
+				return NewBounded[int, int](3, DropOldest)
+			},
+			update: func(tx *Tx[int, int]) error {
+				for i := 4; i <= 8; i++ {
+					tx.Store(i, i*10)
+				}
+
+				require.Equal(t, []string{"6:60", "7:70", "8:80"}, txEntries(tx))
+				return errAbort
+			},
+			expErr:     errAbort,
+			expEntries: []string{"1:10", "2:20", "3:30"},
+		},
+		{
+			name: "error with sized evictions",
+			m: func() *OMap[int, int] {
+				return NewSized[int, int](60, func(val int) int64 { return int64(val) }, DropOldest)
+			},
+			update: func(tx *Tx[int, int]) error {
+				tx.Store(2, 5)
+				tx.Store(4, 50)
+				require.ErrorIs(t, tx.TryStore(5, 70), ErrTooLarge)
+				return errAbort
+			},
+			expErr:     errAbort,
+			expEntries: []string{"1:10", "2:20", "3:30"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := tc.m()
+			for i := 1; i <= 3; i++ {
+				m.Store(i, i*10)
+			}
+
+			stats := m.Stats()
+			size := m.Size()
+
+			var hooked int
+			m.OnInsert(func(int, int) { hooked++ })
+			m.OnRemove(func(int, int) { hooked++ })
+			m.OnUpdate(func(int, int, int) { hooked++ })
+			m.OnEvict(func(int, int) { hooked++ })
+
+			ch := m.Subscribe(context.Background(), 64)
+			defer m.Unsubscribe(ch)
+
+			update := func() {
+				require.ErrorIs(t, m.Update(tc.update), tc.expErr)
+			}
+
+			if tc.panics {
+				require.Panics(t, update)
+			} else {
+				update()
+			}
+
+			require.Equal(t, tc.expEntries, entries(m))
+			require.NoError(t, m.Validate())
+
+			if tc.expErr == nil && !tc.panics {
+				require.NotZero(t, hooked)
+				require.NotEmpty(t, receive(t, ch, 1))
+				return
+			}
+
+			require.Zero(t, hooked)
+			require.Equal(t, size, m.Size())
+			require.Equal(t, stats.Live, m.Stats().Live)
+
+			m.Delete(2)
+			require.Equal(t, []Event[int, int]{
+				{Kind: EventDelete, Key: 2, Val: 20, Seq: 4},
+			}, receive(t, ch, 1))
+		})
+	}
+}
+
+func TestOMap_View(t *testing.T) {
+	t.Parallel()
+
+	m := New[int, int]()
+	for i := 1; i <= 3; i++ {
+		m.Store(i, i*10)
+	}
+
+	m.View(func(tx *Tx[int, int]) {
+		require.Equal(t, 3, tx.Len())
+		require.Equal(t, []string{"1:10", "2:20", "3:30"}, txEntries(tx))
+
+		val, ok := tx.Load(2)
+		require.True(t, ok)
+		require.Equal(t, 20, val)
+
+		require.Panics(t, func() {
+			tx.Delete(1)
+		})
+	})
+
+	require.Equal(t, 3, m.Len())
+}
+
+// entries returns the entries of the map formatted as key:val in order.
+func entries(m *OMap[int, int]) []string {
+	var res []string
+	m.View(func(tx *Tx[int, int]) {
+		res = txEntries(tx)
+	})
+
+	return res
+}
+
+// txEntries returns the entries of the map of the transaction formatted as key:val in order.
+func txEntries(tx *Tx[int, int]) []string {
+	var res []string
+	for it := tx.Iter(); it.Next(); {
+		res = append(res, fmt.Sprintf("%d:%d", it.Key(), it.Val()))
+	}
+
+	return res
+}
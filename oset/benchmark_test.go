@@ -0,0 +1,62 @@
+package oset
+
+import (
+	"testing"
+)
+
+func BenchmarkOSet_Add(b *testing.B) {
+	b.Run("ordered set", func(b *testing.B) {
+		b.ReportAllocs()
+
+		s := New[int]()
+
+		for i := 0; i < b.N; i++ {
+			s.Add(i)
+		}
+	})
+
+	b.Run("presized ordered set", func(b *testing.B) {
+		b.ReportAllocs()
+
+		s := NewPresized[int](b.N)
+
+		for i := 0; i < b.N; i++ {
+			s.Add(i)
+		}
+	})
+}
+
+func BenchmarkOSet_Has(b *testing.B) {
+	b.ReportAllocs()
+
+	s := NewPresized[int](b.N)
+
+	for i := 0; i < b.N; i++ {
+		s.Add(i)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s.Has(i)
+	}
+}
+
+func BenchmarkOSet_Union(b *testing.B) {
+	const (
+		size = 1000
+	)
+
+	x, y := NewPresized[int](size), NewPresized[int](size)
+	for i := 0; i < size; i++ {
+		x.Add(i)
+		y.Add(i + size/2)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
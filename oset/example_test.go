@@ -0,0 +1,24 @@
+package oset_test
+
+import (
+	"fmt"
+
+	"github.com/glebziz/containers/oset"
+)
+
+func ExampleNew() {
+	a := oset.New[string]()
+	a.Add("c")
+	a.Add("a")
+	a.Add("b")
+
+	b := oset.New[string]()
+	b.Add("d")
+	b.Add("a")
+
+	for it := a.Union(b).Iter(); it.Next(); {
+		fmt.Print(it.Val(), " ")
+	}
+
+	// Output: c a b d
+}
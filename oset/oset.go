@@ -0,0 +1,290 @@
+// Package oset implements an insertion ordered set with a double linked list and a node pool.
+//
+// To iterate over a set (where s is a *OSet):
+//
+//	it := s.Iter()
+//	for it.Next() {
+//		// do something with it.Val()
+//	}
+package oset
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/glebziz/containers/internal/iter"
+	"github.com/glebziz/containers/internal/node"
+)
+
+// OSet represents an ordered set.
+// The zero value for OSet is an empty set ready to use.
+type OSet[K comparable] struct {
+	data map[K]*node.Node[K]
+	root node.Node[K]
+	pool *node.Pool[K]
+
+	m sync.RWMutex
+}
+
+// New returns an initialized set.
+func New[K comparable]() *OSet[K] {
+	p := node.NewPool[K]()
+
+	return &OSet[K]{
+		data: make(map[K]*node.Node[K], p.Cap()),
+		pool: p,
+	}
+}
+
+// NewPresized returns an initialized set with an allocated pool of nodes.
+func NewPresized[K comparable](size int) *OSet[K] {
+	return &OSet[K]{
+		data: make(map[K]*node.Node[K], size),
+		pool: node.NewPoolPresized[K](size),
+	}
+}
+
+// Len returns the number of elements of set.
+func (s *OSet[K]) Len() int {
+	return len(s.data)
+}
+
+// Iter returns an iterator of the ordered set.
+func (s *OSet[K]) Iter() *iter.Iter[K] {
+	return iter.New[K](&s.root, iter.ForwardDir)
+}
+
+// Add adds the key to the back of the set and reports whether it was added.
+// Adding an existing key does not change its position.
+// The complexity is O(1).
+func (s *OSet[K]) Add(key K) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.add(key)
+}
+
+// Has reports whether the key is present in the set.
+// The complexity is O(1).
+func (s *OSet[K]) Has(key K) bool {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	_, ok := s.data[key]
+	return ok
+}
+
+// Remove removes the key from the set and reports whether it was present.
+// The complexity is O(1).
+func (s *OSet[K]) Remove(key K) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	n, ok := s.data[key]
+	if ok {
+		delete(s.data, key)
+		n.Remove()
+		s.pool.Push(n)
+	}
+
+	return ok
+}
+
+// Union returns a new set with the keys of s followed by the keys of other not present in s.
+// The complexity is O(len(s) + len(other)).
+func (s *OSet[K]) Union(other *OSet[K]) *OSet[K] {
+	keys := other.keys()
+
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	res := NewPresized[K](len(s.data) + len(keys))
+	s.each(func(key K) {
+		res.add(key)
+	})
+
+	for _, key := range keys {
+		res.add(key)
+	}
+
+	return res
+}
+
+// Intersection returns a new set with the keys of s present in other in the order of s.
+// The complexity is O(len(s) + len(other)).
+func (s *OSet[K]) Intersection(other *OSet[K]) *OSet[K] {
+	in := other.lookup()
+
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	res := New[K]()
+	s.each(func(key K) {
+		if _, ok := in[key]; ok {
+			res.add(key)
+		}
+	})
+
+	return res
+}
+
+// Difference returns a new set with the keys of s not present in other in the order of s.
+// The complexity is O(len(s) + len(other)).
+func (s *OSet[K]) Difference(other *OSet[K]) *OSet[K] {
+	in := other.lookup()
+
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	res := New[K]()
+	s.each(func(key K) {
+		if _, ok := in[key]; !ok {
+			res.add(key)
+		}
+	})
+
+	return res
+}
+
+// SymmetricDifference returns a new set with the keys of s not present in other
+// followed by the keys of other not present in s.
+// The complexity is O(len(s) + len(other)).
+func (s *OSet[K]) SymmetricDifference(other *OSet[K]) *OSet[K] {
+	keys := other.keys()
+
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	in := make(map[K]struct{}, len(keys))
+	for _, key := range keys {
+		in[key] = struct{}{}
+	}
+
+	res := New[K]()
+	s.each(func(key K) {
+		if _, ok := in[key]; !ok {
+			res.add(key)
+		}
+	})
+
+	for _, key := range keys {
+		if _, ok := s.data[key]; !ok {
+			res.add(key)
+		}
+	}
+
+	return res
+}
+
+// IsSubset reports whether every key of s is present in other.
+// The complexity is O(len(s) + len(other)).
+func (s *OSet[K]) IsSubset(other *OSet[K]) bool {
+	in := other.lookup()
+
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	if len(s.data) > len(in) {
+		return false
+	}
+
+	for key := range s.data {
+		if _, ok := in[key]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether s and other contain the same keys regardless of their order.
+// Both sets are read locked in the order of their addresses, so the sets are compared in one state
+// and concurrent calls with the swapped arguments do not deadlock.
+// The complexity is O(len(s)).
+func (s *OSet[K]) Equal(other *OSet[K]) bool {
+	if s == other {
+		return true
+	}
+
+	first, second := s, other
+	if uintptr(unsafe.Pointer(second)) < uintptr(unsafe.Pointer(first)) {
+		first, second = second, first
+	}
+
+	first.m.RLock()
+	defer first.m.RUnlock()
+
+	second.m.RLock()
+	defer second.m.RUnlock()
+
+	if len(s.data) != len(other.data) {
+		return false
+	}
+
+	for key := range s.data {
+		if _, ok := other.data[key]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// add adds the key to the back of the set if it is not present.
+func (s *OSet[K]) add(key K) bool {
+	if _, ok := s.data[key]; ok {
+		return false
+	}
+
+	if s.pool == nil {
+		s.pool = node.NewPool[K]()
+		s.data = make(map[K]*node.Node[K], s.pool.Cap())
+	}
+
+	if s.root.Next() == nil {
+		s.root.SetNext(&s.root)
+		s.root.SetPrev(&s.root)
+	}
+
+	n := s.pool.Pop()
+	n.SetVal(key)
+	s.root.Prev().Insert(n)
+	s.data[key] = n
+
+	return true
+}
+
+// each calls fn for every key of the set in order.
+func (s *OSet[K]) each(fn func(key K)) {
+	for n := s.root.Next(); n != nil && n != &s.root; n = n.Next() {
+		fn(n.Val())
+	}
+}
+
+// keys returns a copy of the keys of the set in order.
+// Copying under the own lock of the set allows binary operations other than Equal
+// to never hold the locks of two sets at the same time.
+func (s *OSet[K]) keys() []K {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	keys := make([]K, 0, len(s.data))
+	s.each(func(key K) {
+		keys = append(keys, key)
+	})
+
+	return keys
+}
+
+// lookup returns a copy of the keys of the set for membership checks.
+func (s *OSet[K]) lookup() map[K]struct{} {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	in := make(map[K]struct{}, len(s.data))
+	for key := range s.data {
+		in[key] = struct{}{}
+	}
+
+	return in
+}
@@ -0,0 +1,276 @@
+package oset
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOSet_Add(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		k       int
+		s       func() *OSet[int]
+		expOk   bool
+		expKeys []int
+	}{
+		{
+			name: "zero set",
+			k:    1,
+			s: func() *OSet[int] {
+				return &OSet[int]{}
+			},
+			expOk:   true,
+			expKeys: []int{1},
+		},
+		{
+			name: "not empty set",
+			k:    3,
+			s: func() *OSet[int] {
+				return newSet(1, 2)
+			},
+			expOk:   true,
+			expKeys: []int{1, 2, 3},
+		},
+		{
+			name: "existing key",
+			k:    1,
+			s: func() *OSet[int] {
+				return newSet(1, 2)
+			},
+			expKeys: []int{1, 2},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.s()
+			require.Equal(t, tc.expOk, s.Add(tc.k))
+			require.Equal(t, tc.expKeys, keys(s))
+			require.Equal(t, len(tc.expKeys), s.Len())
+			require.True(t, s.Has(tc.k))
+		})
+	}
+}
+
+func TestOSet_Remove(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		k       int
+		s       func() *OSet[int]
+		expOk   bool
+		expKeys []int
+	}{
+		{
+			name: "zero set",
+			k:    1,
+			s: func() *OSet[int] {
+				return &OSet[int]{}
+			},
+		},
+		{
+			name: "existing key",
+			k:    2,
+			s: func() *OSet[int] {
+				return newSet(1, 2, 3)
+			},
+			expOk:   true,
+			expKeys: []int{1, 3},
+		},
+		{
+			name: "key not found",
+			k:    10,
+			s: func() *OSet[int] {
+				return newSet(1, 2)
+			},
+			expKeys: []int{1, 2},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.s()
+			require.Equal(t, tc.expOk, s.Remove(tc.k))
+			require.Equal(t, tc.expKeys, keys(s))
+			require.Equal(t, len(tc.expKeys), s.Len())
+			require.False(t, s.Has(tc.k))
+		})
+	}
+}
+
+func TestOSet_Algebra(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		op      func(s, other *OSet[int]) *OSet[int]
+		s       *OSet[int]
+		other   *OSet[int]
+		expKeys []int
+	}{
+		{
+			name:    "union",
+			op:      (*OSet[int]).Union,
+			s:       newSet(3, 1, 2),
+			other:   newSet(4, 2, 5),
+			expKeys: []int{3, 1, 2, 4, 5},
+		},
+		{
+			name:    "union with zero set",
+			op:      (*OSet[int]).Union,
+			s:       &OSet[int]{},
+			other:   newSet(2, 1),
+			expKeys: []int{2, 1},
+		},
+		{
+			name:    "intersection",
+			op:      (*OSet[int]).Intersection,
+			s:       newSet(3, 1, 2),
+			other:   newSet(2, 4, 3),
+			expKeys: []int{3, 2},
+		},
+		{
+			name:  "intersection without common keys",
+			op:    (*OSet[int]).Intersection,
+			s:     newSet(1, 2),
+			other: newSet(3, 4),
+		},
+		{
+			name:    "difference",
+			op:      (*OSet[int]).Difference,
+			s:       newSet(3, 1, 2),
+			other:   newSet(2, 4),
+			expKeys: []int{3, 1},
+		},
+		{
+			name:    "symmetric difference",
+			op:      (*OSet[int]).SymmetricDifference,
+			s:       newSet(3, 1, 2),
+			other:   newSet(5, 2, 4),
+			expKeys: []int{3, 1, 5, 4},
+		},
+		{
+			name:    "same set",
+			op:      (*OSet[int]).SymmetricDifference,
+			s:       newSet(1, 2),
+			expKeys: nil,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			other := tc.other
+			if other == nil {
+				other = tc.s
+			}
+
+			res := tc.op(tc.s, other)
+			require.Equal(t, tc.expKeys, keys(res))
+			require.Equal(t, len(tc.expKeys), res.Len())
+		})
+	}
+}
+
+func TestOSet_IsSubset(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		s        *OSet[int]
+		other    *OSet[int]
+		expSub   bool
+		expEqual bool
+	}{
+		{
+			name:     "zero sets",
+			s:        &OSet[int]{},
+			other:    &OSet[int]{},
+			expSub:   true,
+			expEqual: true,
+		},
+		{
+			name:   "subset",
+			s:      newSet(2, 1),
+			other:  newSet(1, 2, 3),
+			expSub: true,
+		},
+		{
+			name:  "superset",
+			s:     newSet(1, 2, 3),
+			other: newSet(1, 2),
+		},
+		{
+			name:     "equal with different order",
+			s:        newSet(1, 2, 3),
+			other:    newSet(3, 2, 1),
+			expSub:   true,
+			expEqual: true,
+		},
+		{
+			name:  "same len with different keys",
+			s:     newSet(1, 2),
+			other: newSet(1, 3),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expSub, tc.s.IsSubset(tc.other))
+			require.Equal(t, tc.expEqual, tc.s.Equal(tc.other))
+		})
+	}
+}
+
+func TestOSet_EqualConcurrent(t *testing.T) {
+	const N = 1000
+
+	a, b := newSet(1, 2, 3), newSet(3, 2, 1)
+	require.True(t, a.Equal(a))
+
+	var wg sync.WaitGroup
+	for _, fn := range []func(i int){
+		func(int) { a.Equal(b) },
+		func(int) { b.Equal(a) },
+		func(i int) {
+			a.Add(i)
+			a.Remove(i)
+		},
+		func(i int) {
+			b.Add(i)
+			b.Remove(i)
+		},
+	} {
+		fn := fn
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < N; i++ {
+				fn(i + 10)
+			}
+		}()
+	}
+
+	wg.Wait()
+	require.True(t, a.Equal(b))
+}
+
+// newSet returns a set with the keys.
+func newSet(keys ...int) *OSet[int] {
+	s := NewPresized[int](len(keys))
+	for _, k := range keys {
+		s.Add(k)
+	}
+
+	return s
+}
+
+// keys returns the keys of the set in order.
+func keys(s *OSet[int]) []int {
+	var keys []int
+	for it := s.Iter(); it.Next(); {
+		keys = append(keys, it.Val())
+	}
+
+	return keys
+}
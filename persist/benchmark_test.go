@@ -0,0 +1,66 @@
+package persist
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/glebziz/containers/omap"
+)
+
+func BenchmarkWriter_Store(b *testing.B) {
+	b.ReportAllocs()
+
+	w := NewWriter[string, string](io.Discard, StringCodec{}, StringCodec{})
+
+	for i := 0; i < b.N; i++ {
+		w.Store("key", "value")
+	}
+}
+
+func BenchmarkReplay(b *testing.B) {
+	var log bytes.Buffer
+	w := NewWriter[string, string](&log, StringCodec{}, StringCodec{})
+	for i := 0; i < 1000; i++ {
+		w.Store(strconv.Itoa(i%100), "value")
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m := omap.NewPresized[string, string](100)
+		Replay(bytes.NewReader(log.Bytes()), m, StringCodec{}, StringCodec{})
+	}
+}
+
+func BenchmarkDB_Store(b *testing.B) {
+	b.Run("without sync", func(b *testing.B) {
+		b.ReportAllocs()
+
+		db, err := Open(b.TempDir(), omap.New[string, int](), StringCodec{}, JSONCodec[int]{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		defer db.Close()
+
+		for i := 0; i < b.N; i++ {
+			db.Store(strconv.Itoa(i%1000), i)
+		}
+	})
+
+	b.Run("compact every 1000", func(b *testing.B) {
+		b.ReportAllocs()
+
+		db, err := Open(b.TempDir(), omap.New[string, int](), StringCodec{}, JSONCodec[int]{}, WithCompactEvery(1000))
+		if err != nil {
+			b.Fatal(err)
+		}
+		defer db.Close()
+
+		for i := 0; i < b.N; i++ {
+			db.Store(strconv.Itoa(i%1000), i)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package persist
+
+import (
+	"encoding/json"
+)
+
+// Codec encodes and decodes the keys or the values of a map.
+type Codec[T any] interface {
+	Marshal(v T) ([]byte, error)
+	Unmarshal(data []byte) (T, error)
+}
+
+// StringCodec is the codec of strings storing their bytes as is.
+type StringCodec struct{}
+
+// Marshal returns the bytes of the string.
+func (StringCodec) Marshal(v string) ([]byte, error) {
+	return []byte(v), nil
+}
+
+// Unmarshal returns the string of the bytes.
+func (StringCodec) Unmarshal(data []byte) (string, error) {
+	return string(data), nil
+}
+
+// BytesCodec is the codec of byte slices storing them as is.
+type BytesCodec struct{}
+
+// Marshal returns the byte slice.
+func (BytesCodec) Marshal(v []byte) ([]byte, error) {
+	return v, nil
+}
+
+// Unmarshal returns a copy of the bytes.
+func (BytesCodec) Unmarshal(data []byte) ([]byte, error) {
+	return append([]byte(nil), data...), nil
+}
+
+// JSONCodec is the codec of any type encoded as JSON.
+type JSONCodec[T any] struct{}
+
+// Marshal returns the JSON encoding of the value.
+func (JSONCodec[T]) Marshal(v T) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes the value from JSON.
+func (JSONCodec[T]) Unmarshal(data []byte) (v T, err error) {
+	err = json.Unmarshal(data, &v)
+	return v, err
+}
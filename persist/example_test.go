@@ -0,0 +1,61 @@
+package persist_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/glebziz/containers/omap"
+	"github.com/glebziz/containers/persist"
+)
+
+func ExampleOpen() {
+	dir, err := os.MkdirTemp("", "registry")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := persist.Open(dir, omap.New[string, int](), persist.StringCodec{}, persist.JSONCodec[int]{})
+	if err != nil {
+		panic(err)
+	}
+
+	db.Store("a", 1)
+	db.Store("b", 2)
+	db.Compact()
+	db.MoveToFront("b")
+	db.Close()
+
+	db, err = persist.Open(dir, omap.New[string, int](), persist.StringCodec{}, persist.JSONCodec[int]{})
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+
+	for it := db.Map().Iter(); it.Next(); {
+		fmt.Print(it.Key(), "=", it.Val(), " ")
+	}
+
+	// Output: b=2 a=1
+}
+
+func ExampleReplay() {
+	var log bytes.Buffer
+
+	w := persist.NewWriter[string, string](&log, persist.StringCodec{}, persist.StringCodec{})
+	w.Store("host", "localhost")
+	w.Store("port", "8080")
+	w.Delete("host")
+
+	m := omap.New[string, string]()
+	if _, err := persist.Replay(&log, m, persist.StringCodec{}, persist.StringCodec{}); err != nil {
+		panic(err)
+	}
+
+	for it := m.Iter(); it.Next(); {
+		fmt.Println(it.Key(), it.Val())
+	}
+
+	// Output: port 8080
+}
@@ -0,0 +1,125 @@
+package persist
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/glebziz/containers/omap"
+)
+
+// Writer appends the operations on a map to a log.
+// Every operation is written by a single Write call, so a crash leaves at most the last record truncated.
+// Writer is not safe for concurrent use.
+type Writer[K comparable, V any] struct {
+	w   io.Writer
+	key Codec[K]
+	val Codec[V]
+	buf []byte
+
+	// n is the length of the complete records written to w.
+	n int64
+}
+
+// NewWriter returns a log writer to w encoding the keys and the values with the codecs.
+func NewWriter[K comparable, V any](w io.Writer, key Codec[K], val Codec[V]) *Writer[K, V] {
+	return &Writer[K, V]{
+		w:   w,
+		key: key,
+		val: val,
+	}
+}
+
+// Store appends the store of the value by key to the log.
+func (w *Writer[K, V]) Store(key K, val V) error {
+	v, err := w.val.Marshal(val)
+	if err != nil {
+		return fmt.Errorf("marshal value: %w", err)
+	}
+
+	return w.write(opStore, key, v)
+}
+
+// Delete appends the delete of the key to the log.
+func (w *Writer[K, V]) Delete(key K) error {
+	return w.write(opDelete, key, nil)
+}
+
+// MoveToFront appends the move of the key to the front of the map to the log.
+func (w *Writer[K, V]) MoveToFront(key K) error {
+	return w.write(opMoveFront, key, nil)
+}
+
+// MoveToBack appends the move of the key to the back of the map to the log.
+func (w *Writer[K, V]) MoveToBack(key K) error {
+	return w.write(opMoveBack, key, nil)
+}
+
+// write encodes the record and writes it to the log.
+func (w *Writer[K, V]) write(o op, key K, val []byte) error {
+	k, err := w.key.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("marshal key: %w", err)
+	}
+
+	w.buf = appendRecord(w.buf[:0], o, k, val)
+	if _, err = w.w.Write(w.buf); err != nil {
+		return err
+	}
+
+	w.n += int64(len(w.buf))
+	return nil
+}
+
+// Replay applies the operations of the log from r to the map and returns the length of the applied records.
+// If the last record of the log is incomplete or damaged, the records before it are applied
+// and ErrTruncated is returned, so the log can be truncated to the returned length and appended.
+// The stores failing with omap.ErrTooLarge or omap.ErrFull are skipped,
+// because they did not change the map when they were logged.
+func Replay[K comparable, V any](r io.Reader, m *omap.OMap[K, V], key Codec[K], val Codec[V]) (int64, error) {
+	rd := newReader(r)
+	for {
+		rec, err := rd.next()
+		if errors.Is(err, io.EOF) {
+			return rd.off, nil
+		}
+
+		if err != nil {
+			return rd.off, err
+		}
+
+		if err = apply(m, rec, key, val); err != nil {
+			return rd.off, err
+		}
+	}
+}
+
+// apply applies the operation of the record to the map.
+func apply[K comparable, V any](m *omap.OMap[K, V], rec record, key Codec[K], val Codec[V]) error {
+	k, err := key.Unmarshal(rec.key)
+	if err != nil {
+		return fmt.Errorf("unmarshal key: %w", err)
+	}
+
+	switch rec.op {
+	case opStore:
+		v, err := val.Unmarshal(rec.val)
+		if err != nil {
+			return fmt.Errorf("unmarshal value: %w", err)
+		}
+
+		if err = m.TryStore(k, v); err != nil && !errors.Is(err, omap.ErrTooLarge) && !errors.Is(err, omap.ErrFull) {
+			return err
+		}
+	case opDelete:
+		m.Delete(k)
+	case opMoveFront:
+		m.MoveToFront(k)
+	case opMoveBack:
+		m.MoveToBack(k)
+	default:
+		return fmt.Errorf("%w: unexpected operation %d in log", ErrCorrupted, rec.op)
+	}
+
+	return nil
+}
@@ -0,0 +1,459 @@
+// Package persist implements the persistence of an ordered map with a write-ahead log and snapshots.
+//
+// Every change of the map is appended to the log before it is applied,
+// the log is periodically compacted into a snapshot of the map,
+// and the map is restored in identical order from the snapshot and the log:
+//
+//	m := omap.New[string, int]()
+//	db, err := persist.Open(dir, m, persist.StringCodec{}, persist.JSONCodec[int]{})
+//	if err != nil {
+//		// handle error
+//	}
+//	defer db.Close()
+//
+//	err = db.Store("a", 1)
+package persist
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/glebziz/containers/omap"
+)
+
+var (
+	// ErrTruncated is returned by Replay when the last record of the log is incomplete or damaged.
+	ErrTruncated = errors.New("persist: truncated record")
+	// ErrCorrupted is returned when a record in the middle of the log or a snapshot is damaged.
+	ErrCorrupted = errors.New("persist: corrupted data")
+	// ErrClosed is returned by the methods of a closed DB.
+	ErrClosed = errors.New("persist: db is closed")
+)
+
+const (
+	logPrefix      = "wal-"
+	snapshotPrefix = "snapshot-"
+	tmpSuffix      = ".tmp"
+)
+
+// Option configures a DB.
+type Option func(o *options)
+
+// options are the configurable parameters of a DB.
+type options struct {
+	compactEvery int
+	sync         bool
+}
+
+// WithCompactEvery makes the DB compact the log into a snapshot after every n logged operations.
+// By default, the log is compacted only by Compact.
+func WithCompactEvery(n int) Option {
+	return func(o *options) {
+		o.compactEvery = n
+	}
+}
+
+// WithSync makes the DB sync the log to the disk after every operation,
+// so an acknowledged change survives a crash of the machine, not only of the process.
+func WithSync() Option {
+	return func(o *options) {
+		o.sync = true
+	}
+}
+
+// DB persists the changes of an ordered map made through it in a directory.
+// The directory holds the snapshot and the log of the current generation,
+// a compaction starts the next generation, so a crash at any moment leaves a consistent state.
+// The map may be read directly, but the changes made bypassing the DB are not persisted.
+type DB[K comparable, V any] struct {
+	m   *omap.OMap[K, V]
+	dir string
+	key Codec[K]
+	val Codec[V]
+	opt options
+
+	mu      sync.Mutex
+	gen     uint64
+	file    *os.File
+	log     *Writer[K, V]
+	logged  int
+	closed  bool
+	onError func(err error)
+}
+
+// Open restores the map from the latest snapshot and log of the directory and opens the log for appending.
+// The map is expected to be empty and configured as when the changes were logged,
+// so the evictions of a bounded or sized map are reproduced.
+// A truncated last record of the log is discarded.
+func Open[K comparable, V any](dir string, m *omap.OMap[K, V], key Codec[K], val Codec[V], opts ...Option) (*DB[K, V], error) {
+	db := &DB[K, V]{
+		m:   m,
+		dir: dir,
+		key: key,
+		val: val,
+	}
+	for _, opt := range opts {
+		opt(&db.opt)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	gen, ok, err := db.latest()
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		db.gen = gen
+		if err = db.readSnapshot(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = db.openLog(); err != nil {
+		return nil, err
+	}
+
+	if err = db.cleanup(); err != nil {
+		db.file.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Map returns the persisted map.
+func (db *DB[K, V]) Map() *omap.OMap[K, V] {
+	return db.m
+}
+
+// Store logs and stores the value by key.
+// ErrTooLarge and ErrFull of the map are returned after the store is logged, the replay skips such stores.
+func (db *DB[K, V]) Store(key K, val V) error {
+	return db.do(func() error {
+		return db.log.Store(key, val)
+	}, func() error {
+		return db.m.TryStore(key, val)
+	})
+}
+
+// Delete logs and removes the value by key.
+func (db *DB[K, V]) Delete(key K) error {
+	return db.do(func() error {
+		return db.log.Delete(key)
+	}, func() error {
+		db.m.Delete(key)
+		return nil
+	})
+}
+
+// MoveToFront logs and moves the entry by key to the front of the map.
+func (db *DB[K, V]) MoveToFront(key K) error {
+	return db.do(func() error {
+		return db.log.MoveToFront(key)
+	}, func() error {
+		db.m.MoveToFront(key)
+		return nil
+	})
+}
+
+// MoveToBack logs and moves the entry by key to the back of the map.
+func (db *DB[K, V]) MoveToBack(key K) error {
+	return db.do(func() error {
+		return db.log.MoveToBack(key)
+	}, func() error {
+		db.m.MoveToBack(key)
+		return nil
+	})
+}
+
+// OnCompactError sets the function called with the error of a failed automatic compaction.
+// The change that triggered the compaction is already logged and applied, so its method returns no error,
+// and the compaction is retried after the next logged operation.
+// The function is called after the DB is unlocked, so it may use the DB.
+func (db *DB[K, V]) OnCompactError(fn func(err error)) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.onError = fn
+}
+
+// Compact writes the snapshot of the map and starts a new empty log.
+// If the new log cannot be created after the snapshot is written, the DB is closed,
+// because the changes appended to the previous log would not be restored.
+func (db *DB[K, V]) Compact() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.closed {
+		return ErrClosed
+	}
+
+	return db.compact()
+}
+
+// Sync commits the log to the disk.
+func (db *DB[K, V]) Sync() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.closed {
+		return ErrClosed
+	}
+
+	return db.file.Sync()
+}
+
+// Close syncs and closes the log.
+func (db *DB[K, V]) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.closed {
+		return nil
+	}
+
+	db.closed = true
+	return errors.Join(db.file.Sync(), db.file.Close())
+}
+
+// do logs the operation, applies it to the map and compacts the log if it is due.
+// The error of the compaction is passed to the OnCompactError function instead of being returned.
+func (db *DB[K, V]) do(log, apply func() error) error {
+	db.mu.Lock()
+
+	err, compactErr := db.run(log, apply)
+	fn := db.onError
+	db.mu.Unlock()
+
+	if compactErr != nil && fn != nil {
+		fn(compactErr)
+	}
+
+	return err
+}
+
+// run logs the operation, applies it to the map and compacts the log if it is due.
+// A failed write or sync truncates the log back to the end of the last complete record,
+// so the next records are not appended after a torn one.
+func (db *DB[K, V]) run(log, apply func() error) (err, compactErr error) {
+	if db.closed {
+		return ErrClosed, nil
+	}
+
+	size := db.log.n
+	err = log()
+	if err == nil && db.opt.sync {
+		err = db.file.Sync()
+	}
+
+	if err != nil {
+		return db.rollback(size, err), nil
+	}
+
+	db.logged++
+	if err = apply(); err != nil {
+		return err, nil
+	}
+
+	if db.opt.compactEvery > 0 && db.logged >= db.opt.compactEvery {
+		return nil, db.compact()
+	}
+
+	return nil, nil
+}
+
+// rollback truncates the log to the size after the failed operation.
+// If the log cannot be truncated, the DB is closed, because a torn record in the middle of the log
+// would make the directory unreadable.
+func (db *DB[K, V]) rollback(size int64, err error) error {
+	terr := db.file.Truncate(size)
+	if terr == nil {
+		_, terr = db.file.Seek(size, io.SeekStart)
+	}
+
+	if terr != nil {
+		db.closed = true
+		return errors.Join(err, terr, db.file.Close())
+	}
+
+	db.log.n = size
+	return err
+}
+
+// compact writes the snapshot of the next generation, switches to its empty log
+// and removes the files of the previous generation.
+func (db *DB[K, V]) compact() error {
+	gen := db.gen + 1
+	path := db.path(snapshotPrefix, gen)
+
+	f, err := os.Create(path + tmpSuffix)
+	if err != nil {
+		return err
+	}
+
+	err = WriteSnapshot(f, db.m, db.key, db.val)
+	if err == nil {
+		err = f.Sync()
+	}
+
+	if err = errors.Join(err, f.Close()); err != nil {
+		os.Remove(path + tmpSuffix)
+		return err
+	}
+
+	if err = os.Rename(path+tmpSuffix, path); err != nil {
+		os.Remove(path + tmpSuffix)
+		return err
+	}
+
+	// The renamed snapshot is restored on the next open instead of the current log,
+	// so the DB either switches to the log of the new generation or stops logging.
+	f, err = os.OpenFile(db.path(logPrefix, gen), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		db.closed = true
+		return errors.Join(err, db.file.Close())
+	}
+
+	prev := db.file
+	db.gen = gen
+	db.setLog(f, 0)
+
+	if err = errors.Join(prev.Close(), syncDir(db.dir)); err != nil {
+		return err
+	}
+
+	if err = db.cleanup(); err != nil {
+		return err
+	}
+
+	return syncDir(db.dir)
+}
+
+// readSnapshot restores the map from the snapshot of the current generation.
+func (db *DB[K, V]) readSnapshot() error {
+	f, err := os.Open(db.path(snapshotPrefix, db.gen))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err = ReadSnapshot(f, db.m, db.key, db.val); err != nil {
+		return fmt.Errorf("read snapshot %d: %w", db.gen, err)
+	}
+
+	return nil
+}
+
+// openLog replays the log of the current generation, truncates its damaged tail and opens it for appending.
+func (db *DB[K, V]) openLog() error {
+	f, err := os.OpenFile(db.path(logPrefix, db.gen), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+
+	n, err := Replay(f, db.m, db.key, db.val)
+	switch {
+	case errors.Is(err, ErrTruncated):
+		if err = f.Truncate(n); err != nil {
+			f.Close()
+			return err
+		}
+	case err != nil:
+		f.Close()
+		return fmt.Errorf("replay log %d: %w", db.gen, err)
+	}
+
+	if _, err = f.Seek(n, io.SeekStart); err != nil {
+		f.Close()
+		return err
+	}
+
+	db.setLog(f, n)
+
+	return nil
+}
+
+// setLog makes the file with the records of the length n the log of the DB.
+func (db *DB[K, V]) setLog(f *os.File, n int64) {
+	db.file = f
+	db.log = NewWriter(f, db.key, db.val)
+	db.log.n = n
+	db.logged = 0
+}
+
+// latest returns the generation of the latest complete snapshot.
+func (db *DB[K, V]) latest() (gen uint64, ok bool, err error) {
+	entries, err := os.ReadDir(db.dir)
+	if err != nil {
+		return 0, false, err
+	}
+
+	for _, e := range entries {
+		g, isSnapshot := parse(e.Name(), snapshotPrefix)
+		if isSnapshot && (!ok || g > gen) {
+			gen, ok = g, true
+		}
+	}
+
+	return gen, ok, nil
+}
+
+// cleanup removes the files of the previous generations and the incomplete snapshots.
+func (db *DB[K, V]) cleanup() error {
+	entries, err := os.ReadDir(db.dir)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		name := e.Name()
+
+		g, ok := parse(name, snapshotPrefix)
+		if !ok {
+			g, ok = parse(name, logPrefix)
+		}
+
+		if (ok && g < db.gen) || (strings.HasPrefix(name, snapshotPrefix) && strings.HasSuffix(name, tmpSuffix)) {
+			if err = os.Remove(filepath.Join(db.dir, name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// path returns the path of the file of the generation.
+func (db *DB[K, V]) path(prefix string, gen uint64) string {
+	return filepath.Join(db.dir, prefix+strconv.FormatUint(gen, 10))
+}
+
+// parse returns the generation of the file name with the prefix.
+func parse(name, prefix string) (uint64, bool) {
+	s, ok := strings.CutPrefix(name, prefix)
+	if !ok {
+		return 0, false
+	}
+
+	gen, err := strconv.ParseUint(s, 10, 64)
+	return gen, err == nil
+}
+
+// syncDir commits the entries of the directory to the disk.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(d.Sync(), d.Close())
+}
@@ -0,0 +1,429 @@
+package persist
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/glebziz/containers/omap"
+)
+
+func TestDB_Restore(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		m          func() *omap.OMap[string, int]
+		opts       []Option
+		change     func(t *testing.T, db *DB[string, int])
+		expEntries []string
+		expFiles   []string
+	}{
+		{
+			name: "log",
+			m:    omap.New[string, int],
+			change: func(t *testing.T, db *DB[string, int]) {
+				require.NoError(t, db.Store("a", 1))
+				require.NoError(t, db.Store("b", 2))
+				require.NoError(t, db.Store("c", 3))
+				require.NoError(t, db.Store("a", 4))
+				require.NoError(t, db.Delete("b"))
+				require.NoError(t, db.MoveToFront("c"))
+				require.NoError(t, db.Store("d", 5))
+				require.NoError(t, db.MoveToBack("a"))
+			},
+			expEntries: []string{"c:3", "d:5", "a:4"},
+			expFiles:   []string{"wal-0"},
+		},
+		{
+			name: "compact",
+			m:    omap.New[string, int],
+			change: func(t *testing.T, db *DB[string, int]) {
+				require.NoError(t, db.Store("a", 1))
+				require.NoError(t, db.Store("b", 2))
+				require.NoError(t, db.Compact())
+				require.NoError(t, db.Store("c", 3))
+				require.NoError(t, db.MoveToFront("b"))
+			},
+			expEntries: []string{"b:2", "a:1", "c:3"},
+			expFiles:   []string{"snapshot-1", "wal-1"},
+		},
+		{
+			name: "compact every",
+			m:    omap.New[string, int],
+			opts: []Option{WithCompactEvery(3), WithSync()},
+			change: func(t *testing.T, db *DB[string, int]) {
+				for i := 0; i < 10; i++ {
+					require.NoError(t, db.Store(fmt.Sprint(i%4), i))
+				}
+			},
+			expEntries: []string{"2:6", "3:7", "0:8", "1:9"},
+			expFiles:   []string{"snapshot-3", "wal-3"},
+		},
+		{
+			name: "bounded",
+			m: func() *omap.OMap[string, int] {
+				return omap.NewBounded[string, int](2, omap.DropOldest)
+			},
+			change: func(t *testing.T, db *DB[string, int]) {
+				require.NoError(t, db.Store("a", 1))
+				require.NoError(t, db.Store("b", 2))
+				require.NoError(t, db.Store("c", 3))
+				require.NoError(t, db.Store("b", 4))
+				require.NoError(t, db.Store("d", 5))
+			},
+			expEntries: []string{"b:4", "d:5"},
+			expFiles:   []string{"wal-0"},
+		},
+		{
+			name: "too large",
+			m: func() *omap.OMap[string, int] {
+				return omap.NewSized[string, int](10, func(val int) int64 { return int64(val) }, omap.DropOldest)
+			},
+			change: func(t *testing.T, db *DB[string, int]) {
+				require.NoError(t, db.Store("a", 5))
+				require.ErrorIs(t, db.Store("b", 11), omap.ErrTooLarge)
+				require.NoError(t, db.Store("c", 6))
+			},
+			expEntries: []string{"c:6"},
+			expFiles:   []string{"wal-0"},
+		},
+		{
+			name: "rejected",
+			m: func() *omap.OMap[string, int] {
+				return omap.NewBounded[string, int](2, omap.Reject)
+			},
+			change: func(t *testing.T, db *DB[string, int]) {
+				require.NoError(t, db.Store("a", 1))
+				require.NoError(t, db.Store("b", 2))
+				require.ErrorIs(t, db.Store("c", 3), omap.ErrFull)
+				require.NoError(t, db.Delete("a"))
+				require.NoError(t, db.Store("d", 4))
+			},
+			expEntries: []string{"b:2", "d:4"},
+			expFiles:   []string{"wal-0"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			db, err := Open(dir, tc.m(), StringCodec{}, JSONCodec[int]{}, tc.opts...)
+			require.NoError(t, err)
+
+			tc.change(t, db)
+			require.Equal(t, tc.expEntries, entries(db.Map()))
+			require.NoError(t, db.Close())
+			require.ErrorIs(t, db.Store("x", 0), ErrClosed)
+			require.Equal(t, tc.expFiles, files(t, dir))
+
+			db, err = Open(dir, tc.m(), StringCodec{}, JSONCodec[int]{}, tc.opts...)
+			require.NoError(t, err)
+			require.Equal(t, tc.expEntries, entries(db.Map()))
+			require.NoError(t, db.Close())
+		})
+	}
+}
+
+func TestDB_Truncated(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	db, err := Open(dir, omap.New[string, int](), StringCodec{}, JSONCodec[int]{})
+	require.NoError(t, err)
+	require.NoError(t, db.Store("a", 1))
+	require.NoError(t, db.Store("b", 2))
+	require.NoError(t, db.Close())
+
+	path := filepath.Join(dir, "wal-0")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-2))
+
+	db, err = Open(dir, omap.New[string, int](), StringCodec{}, JSONCodec[int]{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a:1"}, entries(db.Map()))
+
+	require.NoError(t, db.Store("c", 3))
+	require.NoError(t, db.Close())
+
+	db, err = Open(dir, omap.New[string, int](), StringCodec{}, JSONCodec[int]{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a:1", "c:3"}, entries(db.Map()))
+	require.NoError(t, db.Close())
+}
+
+func TestDB_Corrupted(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	db, err := Open(dir, omap.New[string, int](), StringCodec{}, JSONCodec[int]{})
+	require.NoError(t, err)
+	require.NoError(t, db.Store("a", 1))
+	require.NoError(t, db.Store("b", 2))
+	require.NoError(t, db.Close())
+
+	path := filepath.Join(dir, "wal-0")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	data[headerSize+2] ^= 0xff
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+
+	_, err = Open(dir, omap.New[string, int](), StringCodec{}, JSONCodec[int]{})
+	require.ErrorIs(t, err, ErrCorrupted)
+}
+
+func TestDB_InterruptedCompaction(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	db, err := Open(dir, omap.New[string, int](), StringCodec{}, JSONCodec[int]{})
+	require.NoError(t, err)
+	require.NoError(t, db.Store("a", 1))
+	require.NoError(t, db.Compact())
+	require.NoError(t, db.Store("b", 2))
+	require.NoError(t, db.Close())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "snapshot-2.tmp"), []byte("partial"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "wal-0"), []byte("stale"), 0o644))
+
+	db, err = Open(dir, omap.New[string, int](), StringCodec{}, JSONCodec[int]{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a:1", "b:2"}, entries(db.Map()))
+	require.Equal(t, []string{"snapshot-1", "wal-1"}, files(t, dir))
+	require.NoError(t, db.Close())
+}
+
+// tornWriter writes the first limit bytes of a record and fails.
+type tornWriter struct {
+	w     io.Writer
+	limit int
+}
+
+func (w tornWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p[:min(len(p), w.limit)])
+	if err != nil {
+		return n, err
+	}
+
+	return n, errors.New("disk is full")
+}
+
+func TestDB_FailedWrite(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	db, err := Open(dir, omap.New[string, int](), StringCodec{}, JSONCodec[int]{})
+	require.NoError(t, err)
+	require.NoError(t, db.Store("a", 1))
+
+	log := db.log.w
+	db.log.w = tornWriter{w: log, limit: headerSize + 1}
+	require.Error(t, db.Store("b", 2))
+	require.Equal(t, []string{"a:1"}, entries(db.Map()))
+
+	db.log.w = log
+	require.NoError(t, db.Store("c", 3))
+	require.NoError(t, db.Close())
+
+	db, err = Open(dir, omap.New[string, int](), StringCodec{}, JSONCodec[int]{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a:1", "c:3"}, entries(db.Map()))
+	require.NoError(t, db.Close())
+}
+
+func TestDB_FailedCompaction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		db, err := Open(dir, omap.New[string, int](), StringCodec{}, JSONCodec[int]{}, WithCompactEvery(2))
+		require.NoError(t, err)
+
+		var errs []error
+		db.OnCompactError(func(err error) {
+			errs = append(errs, err)
+			require.NoError(t, db.Sync())
+		})
+
+		tmp := filepath.Join(dir, "snapshot-1.tmp")
+		require.NoError(t, os.Mkdir(tmp, 0o755))
+
+		require.NoError(t, db.Store("a", 1))
+		require.NoError(t, db.Store("b", 2))
+		require.NoError(t, db.Store("c", 3))
+		require.Len(t, errs, 2)
+		require.Equal(t, []string{"a:1", "b:2", "c:3"}, entries(db.Map()))
+
+		require.NoError(t, os.Remove(tmp))
+		require.NoError(t, db.Store("d", 4))
+		require.Len(t, errs, 2)
+		require.Equal(t, []string{"snapshot-1", "wal-1"}, files(t, dir))
+		require.NoError(t, db.Close())
+
+		db, err = Open(dir, omap.New[string, int](), StringCodec{}, JSONCodec[int]{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a:1", "b:2", "c:3", "d:4"}, entries(db.Map()))
+		require.NoError(t, db.Close())
+	})
+
+	t.Run("log", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		db, err := Open(dir, omap.New[string, int](), StringCodec{}, JSONCodec[int]{})
+		require.NoError(t, err)
+		require.NoError(t, db.Store("a", 1))
+
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "wal-1"), 0o755))
+		require.Error(t, db.Compact())
+		require.ErrorIs(t, db.Store("b", 2), ErrClosed)
+		require.NoError(t, db.Close())
+
+		require.NoError(t, os.Remove(filepath.Join(dir, "wal-1")))
+
+		db, err = Open(dir, omap.New[string, int](), StringCodec{}, JSONCodec[int]{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a:1"}, entries(db.Map()))
+		require.Equal(t, []string{"snapshot-1", "wal-1"}, files(t, dir))
+		require.NoError(t, db.Close())
+	})
+}
+
+func TestReplay_Truncated(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter[string, int](&buf, StringCodec{}, JSONCodec[int]{})
+	require.NoError(t, w.Store("a", 1))
+	require.NoError(t, w.Store("b", 2))
+
+	valid := int64(buf.Len())
+	require.NoError(t, w.Delete("a"))
+
+	log := buf.Bytes()
+	for i := valid; i < int64(len(log)); i++ {
+		m := omap.New[string, int]()
+
+		n, err := Replay(bytes.NewReader(log[:i]), m, StringCodec{}, JSONCodec[int]{})
+		if i == valid {
+			require.NoError(t, err)
+		} else {
+			require.ErrorIs(t, err, ErrTruncated, "length %d", i)
+		}
+
+		require.Equal(t, valid, n)
+		require.Equal(t, []string{"a:1", "b:2"}, entries(m))
+	}
+
+	damaged := append([]byte(nil), log...)
+	damaged[len(damaged)-1] ^= 0xff
+
+	n, err := Replay(bytes.NewReader(damaged), omap.New[string, int](), StringCodec{}, JSONCodec[int]{})
+	require.ErrorIs(t, err, ErrTruncated)
+	require.Equal(t, valid, n)
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	type val struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+
+	m := omap.New[string, val]()
+	m.Store("b", val{Name: "bob", Tags: []string{"x"}})
+	m.Store("a", val{Name: "alice"})
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSnapshot(&buf, m, StringCodec{}, JSONCodec[val]{}))
+
+	restored := omap.New[string, val]()
+	require.NoError(t, ReadSnapshot(bytes.NewReader(buf.Bytes()), restored, StringCodec{}, JSONCodec[val]{}))
+
+	var keys []string
+	for it := restored.Iter(); it.Next(); {
+		keys = append(keys, it.Key())
+	}
+
+	require.Equal(t, []string{"b", "a"}, keys)
+
+	b, _ := restored.Load("b")
+	require.Equal(t, val{Name: "bob", Tags: []string{"x"}}, b)
+
+	for i := 0; i < buf.Len(); i++ {
+		err := ReadSnapshot(bytes.NewReader(buf.Bytes()[:i]), omap.New[string, val](), StringCodec{}, JSONCodec[val]{})
+		require.ErrorIs(t, err, ErrCorrupted, "length %d", i)
+	}
+
+	for _, count := range [][]byte{nil, {0x80}} {
+		end := appendRecord(nil, opEnd, nil, count)
+		err := ReadSnapshot(bytes.NewReader(end), omap.New[string, val](), StringCodec{}, JSONCodec[val]{})
+		require.ErrorIs(t, err, ErrCorrupted, "count %x", count)
+	}
+}
+
+func TestCodec(t *testing.T) {
+	t.Parallel()
+
+	s, err := StringCodec{}.Marshal("key")
+	require.NoError(t, err)
+
+	str, err := StringCodec{}.Unmarshal(s)
+	require.NoError(t, err)
+	require.Equal(t, "key", str)
+
+	data := []byte{1, 2, 3}
+	b, err := BytesCodec{}.Unmarshal(data)
+	require.NoError(t, err)
+	require.Equal(t, data, b)
+
+	data[0] = 0
+	require.Equal(t, byte(1), b[0])
+
+	_, err = JSONCodec[int]{}.Unmarshal([]byte("x"))
+	require.Error(t, err)
+}
+
+// entries returns the entries of the map formatted as key:val in order.
+func entries(m *omap.OMap[string, int]) []string {
+	var res []string
+	for it := m.Iter(); it.Next(); {
+		res = append(res, fmt.Sprintf("%s:%d", it.Key(), it.Val()))
+	}
+
+	return res
+}
+
+// files returns the sorted names of the files of the directory.
+func files(t *testing.T, dir string) []string {
+	t.Helper()
+
+	list, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var names []string
+	for _, e := range list {
+		names = append(names, e.Name())
+	}
+
+	sort.Strings(names)
+	return names
+}
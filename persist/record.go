@@ -0,0 +1,126 @@
+package persist
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// op is the operation of a record.
+type op byte
+
+const (
+	opStore op = iota + 1
+	opDelete
+	opMoveFront
+	opMoveBack
+	// opEnd ends a snapshot, its value is the number of the stored entries.
+	opEnd
+)
+
+const (
+	// headerSize is the size of the record header: the length and the checksum of the payload.
+	headerSize = 8
+	// maxPayload is the maximum size of the payload of a record.
+	maxPayload = 1 << 30
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// record is a decoded record: the operation with the encoded key and value.
+type record struct {
+	op  op
+	key []byte
+	val []byte
+}
+
+// appendRecord appends the record to dst.
+// The payload is the operation, the length of the key as uvarint, the key and the value.
+func appendRecord(dst []byte, o op, key, val []byte) []byte {
+	start := len(dst)
+	dst = append(dst, make([]byte, headerSize)...)
+	dst = append(dst, byte(o))
+	dst = binary.AppendUvarint(dst, uint64(len(key)))
+	dst = append(dst, key...)
+	dst = append(dst, val...)
+
+	payload := dst[start+headerSize:]
+	binary.LittleEndian.PutUint32(dst[start:], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(dst[start+4:], crc32.Checksum(payload, crcTable))
+
+	return dst
+}
+
+// reader reads the records and counts the bytes of the complete records.
+type reader struct {
+	r   *bufio.Reader
+	off int64
+	buf []byte
+}
+
+// newReader returns a reader of the records from r.
+func newReader(r io.Reader) *reader {
+	return &reader{
+		r: bufio.NewReader(r),
+	}
+}
+
+// next returns the next record, io.EOF after the last complete record,
+// ErrTruncated if the last record is incomplete or damaged and ErrCorrupted if a record in the middle is damaged.
+// The returned slices are valid until the next call.
+func (r *reader) next() (rec record, err error) {
+	var header [headerSize]byte
+	if _, err = io.ReadFull(r.r, header[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return rec, r.damaged("incomplete header")
+		}
+
+		return rec, err
+	}
+
+	size := binary.LittleEndian.Uint32(header[:])
+	if size == 0 || size > maxPayload {
+		return rec, r.damaged(fmt.Sprintf("invalid length %d", size))
+	}
+
+	if cap(r.buf) < int(size) {
+		r.buf = make([]byte, size)
+	}
+
+	payload := r.buf[:size]
+	if _, err = io.ReadFull(r.r, payload); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			return rec, r.damaged("incomplete payload")
+		}
+
+		return rec, err
+	}
+
+	if crc32.Checksum(payload, crcTable) != binary.LittleEndian.Uint32(header[4:]) {
+		return rec, r.damaged("checksum mismatch")
+	}
+
+	rec.op = op(payload[0])
+	keyLen, n := binary.Uvarint(payload[1:])
+	if n <= 0 || keyLen > uint64(len(payload)-1-n) {
+		return rec, fmt.Errorf("%w: invalid key length at offset %d", ErrCorrupted, r.off)
+	}
+
+	rec.key = payload[1+n : 1+n+int(keyLen)]
+	rec.val = payload[1+n+int(keyLen):]
+	r.off += headerSize + int64(size)
+
+	return rec, nil
+}
+
+// damaged returns ErrTruncated if nothing follows the damaged record and ErrCorrupted otherwise.
+func (r *reader) damaged(reason string) error {
+	if _, err := r.r.Peek(1); err != nil {
+		return fmt.Errorf("%w: %s at offset %d", ErrTruncated, reason, r.off)
+	}
+
+	return fmt.Errorf("%w: %s at offset %d", ErrCorrupted, reason, r.off)
+}
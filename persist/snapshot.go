@@ -0,0 +1,94 @@
+package persist
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/glebziz/containers/omap"
+)
+
+// WriteSnapshot writes all entries of the map to w in order.
+// The snapshot is ended by a record with the number of entries,
+// so an incomplete snapshot is detected by ReadSnapshot.
+func WriteSnapshot[K comparable, V any](w io.Writer, m *omap.OMap[K, V], key Codec[K], val Codec[V]) error {
+	bw := bufio.NewWriter(w)
+
+	var (
+		buf   []byte
+		count uint64
+		err   error
+	)
+	m.View(func(tx *omap.Tx[K, V]) {
+		for it := tx.Iter(); it.Next(); {
+			var k, v []byte
+			if k, err = key.Marshal(it.Key()); err != nil {
+				err = fmt.Errorf("marshal key: %w", err)
+				return
+			}
+
+			if v, err = val.Marshal(it.Val()); err != nil {
+				err = fmt.Errorf("marshal value: %w", err)
+				return
+			}
+
+			buf = appendRecord(buf[:0], opStore, k, v)
+			if _, err = bw.Write(buf); err != nil {
+				return
+			}
+
+			count++
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	buf = appendRecord(buf[:0], opEnd, nil, binary.AppendUvarint(nil, count))
+	if _, err = bw.Write(buf); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// ReadSnapshot stores the entries of the snapshot from r into the map in order.
+// ErrCorrupted is returned if the snapshot is damaged or incomplete.
+func ReadSnapshot[K comparable, V any](r io.Reader, m *omap.OMap[K, V], key Codec[K], val Codec[V]) error {
+	rd := newReader(r)
+
+	var count uint64
+	for {
+		rec, err := rd.next()
+		switch {
+		case errors.Is(err, io.EOF), errors.Is(err, ErrTruncated):
+			return fmt.Errorf("%w: incomplete snapshot", ErrCorrupted)
+		case err != nil:
+			return err
+		}
+
+		switch rec.op {
+		case opStore:
+			if err = apply(m, rec, key, val); err != nil {
+				return err
+			}
+
+			count++
+		case opEnd:
+			n, size := binary.Uvarint(rec.val)
+			if size <= 0 {
+				return fmt.Errorf("%w: invalid entry count in snapshot", ErrCorrupted)
+			}
+
+			if n != count {
+				return fmt.Errorf("%w: snapshot has %d entries, expected %d", ErrCorrupted, count, n)
+			}
+
+			return nil
+		default:
+			return fmt.Errorf("%w: unexpected operation %d in snapshot", ErrCorrupted, rec.op)
+		}
+	}
+}
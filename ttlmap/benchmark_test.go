@@ -0,0 +1,44 @@
+package ttlmap
+
+import (
+	"testing"
+	"time"
+)
+
+func BenchmarkMap_Store(b *testing.B) {
+	b.Run("ttl map", func(b *testing.B) {
+		b.ReportAllocs()
+
+		m := New[int, int](time.Minute)
+
+		for i := 0; i < b.N; i++ {
+			m.Store(i, i)
+		}
+	})
+
+	b.Run("presized ttl map", func(b *testing.B) {
+		b.ReportAllocs()
+
+		m := NewPresized[int, int](time.Minute, b.N)
+
+		for i := 0; i < b.N; i++ {
+			m.Store(i, i)
+		}
+	})
+}
+
+func BenchmarkMap_Load(b *testing.B) {
+	b.ReportAllocs()
+
+	m := NewPresized[int, int](time.Minute, b.N)
+
+	for i := 0; i < b.N; i++ {
+		m.Store(i, i)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.Load(i)
+	}
+}
@@ -0,0 +1,21 @@
+package ttlmap_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/glebziz/containers/ttlmap"
+)
+
+func ExampleNew() {
+	m := ttlmap.New[string, int](time.Minute, ttlmap.WithJanitor(time.Second))
+	defer m.Close()
+
+	m.Store("a", 1)
+	m.StoreWithTTL("b", 2, time.Hour)
+
+	v, ok := m.Load("a")
+	fmt.Println(v, ok)
+
+	// Output: 1 true
+}
@@ -0,0 +1,67 @@
+package ttlmap
+
+import (
+	"time"
+)
+
+// Clock provides the current time and the ticks of the janitor to the map.
+// It allows to test expiration without sleeping.
+type Clock interface {
+	Now() time.Time
+	// NewTicker returns a ticker delivering the time of the clock every d.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers the ticks of a clock.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// systemClock is the clock of the system time.
+type systemClock struct{}
+
+// Now returns the current system time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTicker returns a ticker of the system time.
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+// systemTicker is the ticker of the system time.
+type systemTicker struct {
+	*time.Ticker
+}
+
+// C returns the channel of the ticks.
+func (t systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// Option configures the map.
+type Option func(o *options)
+
+// options are the configurable parameters of the map.
+type options struct {
+	clock   Clock
+	janitor time.Duration
+}
+
+// WithClock sets the clock used to compute expiration times and to drive the janitor.
+func WithClock(c Clock) Option {
+	return func(o *options) {
+		if c != nil {
+			o.clock = c
+		}
+	}
+}
+
+// WithJanitor enables the background goroutine removing expired entries every interval of the map clock.
+func WithJanitor(interval time.Duration) Option {
+	return func(o *options) {
+		o.janitor = interval
+	}
+}
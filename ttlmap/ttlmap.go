@@ -0,0 +1,354 @@
+// Package ttlmap implements an ordered map with per-entry expiration,
+// a double linked list and a node pool.
+//
+// Entries are kept in the order of their expiration time.
+// When all entries share the same TTL this is exactly the insertion order,
+// so a new entry is appended to the back and expired entries are removed from the front in O(1).
+//
+// To iterate over a map (where m is a *Map):
+//
+//	it := m.Iter()
+//	for it.Next() {
+//		// do something with it.Key() and it.Val()
+//	}
+package ttlmap
+
+import (
+	"sync"
+	"time"
+
+	"github.com/glebziz/containers/internal/iter"
+	"github.com/glebziz/containers/internal/node"
+)
+
+// Map represents an ordered map with expiring entries.
+// The zero value for Map is an empty map without expiration ready to use.
+type Map[K comparable, V any] struct {
+	data map[K]*node.Node[node.Entry[K, item[V]]]
+	root node.Node[node.Entry[K, item[V]]]
+	pool *node.Pool[node.Entry[K, item[V]]]
+
+	ttl      time.Duration
+	clock    Clock
+	onExpire func(key K, val V)
+
+	// relock is called by Load between its read and write locks, it lets tests change the map there.
+	relock func()
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+
+	m sync.RWMutex
+}
+
+// item is a value with its expiration time.
+// The zero expiration time means that the value never expires.
+type item[V any] struct {
+	val     V
+	expires time.Time
+}
+
+// Reset resets the value if the pointer to the value implements node.Resetter.
+// It allows the pool to reset the values of the map.
+func (it *item[V]) Reset() {
+	if r, ok := any(&it.val).(node.Resetter); ok {
+		r.Reset()
+	}
+}
+
+// New returns an initialized map with the default TTL of the entries.
+// A TTL less than or equal to zero means that the entries do not expire.
+// If the janitor is enabled, Close must be called to stop it.
+func New[K comparable, V any](ttl time.Duration, opts ...Option) *Map[K, V] {
+	return NewPresized[K, V](ttl, 0, opts...)
+}
+
+// NewPresized returns an initialized map with the default TTL of the entries
+// and an allocated pool of nodes.
+func NewPresized[K comparable, V any](ttl time.Duration, size int, opts ...Option) *Map[K, V] {
+	o := options{
+		clock: systemClock{},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var p *node.Pool[node.Entry[K, item[V]]]
+	if size > 0 {
+		p = node.NewPoolPresized[node.Entry[K, item[V]]](size)
+	} else {
+		p = node.NewPool[node.Entry[K, item[V]]]()
+	}
+
+	m := &Map[K, V]{
+		data:  make(map[K]*node.Node[node.Entry[K, item[V]]], p.Cap()),
+		pool:  p,
+		ttl:   ttl,
+		clock: o.clock,
+	}
+
+	if o.janitor > 0 {
+		m.stop = make(chan struct{})
+		m.done = make(chan struct{})
+		go m.janitor(o.janitor)
+	}
+
+	return m
+}
+
+// OnExpire sets the function called for every expired entry removed from the map.
+// The function is called after the map is unlocked, so it may use the map.
+func (m *Map[K, V]) OnExpire(fn func(key K, val V)) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.onExpire = fn
+}
+
+// Len returns the number of entries of map including expired entries that have not been removed yet.
+func (m *Map[K, V]) Len() int {
+	return len(m.data)
+}
+
+// Iter returns an iterator over the unexpired entries of the map in the order of expiration.
+func (m *Map[K, V]) Iter() *Iter[K, V] {
+	return &Iter[K, V]{
+		it:  iter.New[node.Entry[K, item[V]]](&m.root, iter.ForwardDir),
+		now: m.now(),
+	}
+}
+
+// Store stores the value by key in the map with the default TTL.
+// The complexity is O(1) if all entries share the same TTL.
+func (m *Map[K, V]) Store(key K, val V) {
+	m.StoreWithTTL(key, val, m.ttl)
+}
+
+// StoreWithTTL stores the value by key in the map with the ttl.
+// A ttl less than or equal to zero means that the entry does not expire.
+// The complexity is O(k), where k is the number of entries expiring later than the stored one.
+func (m *Map[K, V]) StoreWithTTL(key K, val V, ttl time.Duration) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	n, ok := m.data[key]
+	if ok {
+		n.Remove()
+	} else {
+		if m.pool == nil {
+			m.pool = node.NewPool[node.Entry[K, item[V]]]()
+			m.data = make(map[K]*node.Node[node.Entry[K, item[V]]], m.pool.Cap())
+		}
+
+		if m.root.Next() == nil {
+			m.root.SetNext(&m.root)
+			m.root.SetPrev(&m.root)
+		}
+
+		n = m.pool.Pop()
+		m.data[key] = n
+	}
+
+	it := item[V]{
+		val: val,
+	}
+	if ttl > 0 {
+		it.expires = m.now().Add(ttl)
+	}
+
+	n.SetVal(node.Entry[K, item[V]]{Key: key, Val: it})
+
+	at := m.root.Prev()
+	for at != &m.root && later(at.Val().Val.expires, it.expires) {
+		at = at.Prev()
+	}
+
+	at.Insert(n)
+}
+
+// Load returns the value by key from the map.
+// An expired entry is removed and reported as not found.
+// The complexity is O(1).
+func (m *Map[K, V]) Load(key K) (val V, ok bool) {
+	now := m.now()
+
+	m.m.RLock()
+	n, ok := m.data[key]
+	e := n.Val()
+	m.m.RUnlock()
+
+	if !ok {
+		return val, false
+	}
+
+	if !expired(e.Val, now) {
+		return e.Val.val, true
+	}
+
+	if m.relock != nil {
+		m.relock()
+	}
+
+	m.m.Lock()
+	n, ok = m.data[key]
+	if !ok {
+		m.m.Unlock()
+		return val, false
+	}
+
+	// The key may have been stored again after the read lock was released.
+	e = n.Val()
+	if !expired(e.Val, now) {
+		m.m.Unlock()
+		return e.Val.val, true
+	}
+
+	m.remove(n)
+	fn := m.onExpire
+	m.m.Unlock()
+
+	if fn != nil {
+		fn(key, e.Val.val)
+	}
+
+	return val, false
+}
+
+// Delete removes the value by key from the map.
+// The complexity is O(1).
+func (m *Map[K, V]) Delete(key K) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	n, ok := m.data[key]
+	if ok {
+		m.remove(n)
+	}
+}
+
+// DeleteExpired removes the expired entries from the front of the map
+// and returns the number of removed entries.
+// The complexity is O(k), where k is the number of expired entries.
+func (m *Map[K, V]) DeleteExpired() int {
+	now := m.now()
+
+	m.m.Lock()
+	var removed []node.Entry[K, V]
+	for n := m.root.Next(); n != nil && n != &m.root; n = m.root.Next() {
+		e := n.Val()
+		if !expired(e.Val, now) {
+			break
+		}
+
+		m.remove(n)
+		removed = append(removed, node.Entry[K, V]{Key: e.Key, Val: e.Val.val})
+	}
+	fn := m.onExpire
+	m.m.Unlock()
+
+	if fn != nil {
+		for _, e := range removed {
+			fn(e.Key, e.Val)
+		}
+	}
+
+	return len(removed)
+}
+
+// Close stops the janitor of the map if it is enabled.
+// It is safe to call Close several times.
+func (m *Map[K, V]) Close() {
+	if m.stop == nil {
+		return
+	}
+
+	m.once.Do(func() {
+		close(m.stop)
+		<-m.done
+	})
+}
+
+// janitor removes the expired entries on every tick of the map clock until the map is closed.
+func (m *Map[K, V]) janitor(interval time.Duration) {
+	defer close(m.done)
+
+	t := m.clock.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C():
+			m.DeleteExpired()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+// remove removes n from the map.
+func (m *Map[K, V]) remove(n *node.Node[node.Entry[K, item[V]]]) {
+	delete(m.data, n.Val().Key)
+	n.Remove()
+	m.pool.Push(n)
+}
+
+// now returns the current time of the map clock.
+func (m *Map[K, V]) now() time.Time {
+	if m.clock == nil {
+		return time.Now()
+	}
+
+	return m.clock.Now()
+}
+
+// expired reports whether the item is expired at the time now.
+func expired[V any](it item[V], now time.Time) bool {
+	return !it.expires.IsZero() && !now.Before(it.expires)
+}
+
+// later reports whether the expiration time a is later than b.
+func later(a, b time.Time) bool {
+	if a.IsZero() {
+		return !b.IsZero()
+	}
+
+	if b.IsZero() {
+		return false
+	}
+
+	return a.After(b)
+}
+
+// Iter is an iterator over the unexpired entries of the map.
+type Iter[K comparable, V any] struct {
+	it  *iter.Iter[node.Entry[K, item[V]]]
+	now time.Time
+}
+
+// Next selects the next unexpired entry and returns true if it exists.
+// Otherwise, it returns false.
+func (i *Iter[K, V]) Next() bool {
+	for i.it.Next() {
+		if !expired(i.it.Val().Val, i.now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Key returns the key of the current entry.
+func (i *Iter[K, V]) Key() K {
+	return i.it.Val().Key
+}
+
+// Val returns the value of the current entry.
+func (i *Iter[K, V]) Val() V {
+	return i.it.Val().Val.val
+}
+
+// Expires returns the expiration time of the current entry or zero time if it does not expire.
+func (i *Iter[K, V]) Expires() time.Time {
+	return i.it.Val().Val.expires
+}
@@ -0,0 +1,332 @@
+package ttlmap
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a manually advanced clock.
+type fakeClock struct {
+	now     time.Time
+	tickers []*fakeTicker
+	m       sync.Mutex
+}
+
+// fakeTicker is a ticker of the fake clock.
+type fakeTicker struct {
+	c    chan time.Time
+	d    time.Duration
+	next time.Time
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Stop() {}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) Ticker {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	t := &fakeTicker{
+		c:    make(chan time.Time, 1),
+		d:    d,
+		next: c.now.Add(d),
+	}
+	c.tickers = append(c.tickers, t)
+
+	return t
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.now = c.now.Add(d)
+	for _, t := range c.tickers {
+		if c.now.Before(t.next) {
+			continue
+		}
+
+		for !c.now.Before(t.next) {
+			t.next = t.next.Add(t.d)
+		}
+
+		select {
+		case t.c <- c.now:
+		default:
+		}
+	}
+}
+
+type pair struct {
+	k int
+	v int
+}
+
+func TestMap_Store(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		ops      func(m *Map[int, int], c *fakeClock)
+		expPairs []pair
+	}{
+		{
+			name: "same ttl keeps insertion order",
+			ops: func(m *Map[int, int], c *fakeClock) {
+				m.Store(3, 30)
+				c.Advance(time.Second)
+				m.Store(1, 10)
+				c.Advance(time.Second)
+				m.Store(2, 20)
+			},
+			expPairs: []pair{{3, 30}, {1, 10}, {2, 20}},
+		},
+		{
+			name: "shorter ttl is inserted before",
+			ops: func(m *Map[int, int], c *fakeClock) {
+				m.Store(1, 10)
+				m.Store(2, 20)
+				m.StoreWithTTL(3, 30, time.Second)
+			},
+			expPairs: []pair{{3, 30}, {1, 10}, {2, 20}},
+		},
+		{
+			name: "entries without ttl are at the back",
+			ops: func(m *Map[int, int], c *fakeClock) {
+				m.StoreWithTTL(1, 10, 0)
+				m.Store(2, 20)
+				m.StoreWithTTL(3, 30, -1)
+			},
+			expPairs: []pair{{2, 20}, {1, 10}, {3, 30}},
+		},
+		{
+			name: "existing key",
+			ops: func(m *Map[int, int], c *fakeClock) {
+				m.Store(1, 10)
+				m.Store(2, 20)
+				c.Advance(time.Second)
+				m.Store(1, 11)
+			},
+			expPairs: []pair{{2, 20}, {1, 11}},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := newFakeClock()
+			m := New[int, int](time.Minute, WithClock(c))
+			tc.ops(m, c)
+			require.Equal(t, tc.expPairs, pairs(m))
+			require.Equal(t, len(tc.expPairs), m.Len())
+		})
+	}
+}
+
+func TestMap_Load(t *testing.T) {
+	c := newFakeClock()
+	m := New[int, int](time.Minute, WithClock(c))
+
+	var expired []pair
+	m.OnExpire(func(k, v int) {
+		expired = append(expired, pair{k, v})
+	})
+
+	m.Store(1, 10)
+	m.StoreWithTTL(2, 20, 2*time.Minute)
+
+	v, ok := m.Load(1)
+	require.True(t, ok)
+	require.Equal(t, 10, v)
+
+	c.Advance(time.Minute)
+
+	v, ok = m.Load(1)
+	require.False(t, ok)
+	require.Zero(t, v)
+	require.Equal(t, []pair{{1, 10}}, expired)
+	require.Equal(t, 1, m.Len())
+
+	v, ok = m.Load(2)
+	require.True(t, ok)
+	require.Equal(t, 20, v)
+
+	_, ok = m.Load(3)
+	require.False(t, ok)
+}
+
+func TestMap_LoadStoredAgain(t *testing.T) {
+	c := newFakeClock()
+	m := New[int, int](time.Minute, WithClock(c))
+
+	var expired []pair
+	m.OnExpire(func(k, v int) {
+		expired = append(expired, pair{k, v})
+	})
+
+	m.Store(1, 10)
+	c.Advance(time.Minute)
+
+	m.relock = func() {
+		m.Store(1, 11)
+	}
+
+	v, ok := m.Load(1)
+	require.True(t, ok)
+	require.Equal(t, 11, v)
+	require.Empty(t, expired)
+	require.Equal(t, 1, m.Len())
+}
+
+func TestMap_Delete(t *testing.T) {
+	m := New[int, int](0)
+	m.Store(1, 10)
+	m.Store(2, 20)
+
+	m.Delete(1)
+	m.Delete(3)
+
+	require.Equal(t, []pair{{2, 20}}, pairs(m))
+	require.Equal(t, 1, m.Len())
+}
+
+type resetVal struct {
+	resets *int
+}
+
+func (v *resetVal) Reset() {
+	*v.resets++
+}
+
+func TestMap_ResetValues(t *testing.T) {
+	c := newFakeClock()
+	m := New[int, resetVal](time.Minute, WithClock(c))
+
+	resets := 0
+	m.Store(1, resetVal{resets: &resets})
+	m.Store(2, resetVal{resets: &resets})
+	m.Store(3, resetVal{resets: &resets})
+
+	m.Delete(1)
+	require.Equal(t, 1, resets)
+
+	c.Advance(time.Minute)
+	_, ok := m.Load(2)
+	require.False(t, ok)
+	require.Equal(t, 2, resets)
+
+	require.Equal(t, 1, m.DeleteExpired())
+	require.Equal(t, 3, resets)
+}
+
+func TestMap_DeleteExpired(t *testing.T) {
+	c := newFakeClock()
+	m := NewPresized[int, int](time.Minute, 4, WithClock(c))
+
+	var expired []pair
+	m.OnExpire(func(k, v int) {
+		expired = append(expired, pair{k, v})
+	})
+
+	m.Store(1, 10)
+	c.Advance(time.Second)
+	m.Store(2, 20)
+	m.StoreWithTTL(3, 30, time.Second)
+	m.StoreWithTTL(4, 40, 0)
+
+	require.Zero(t, m.DeleteExpired())
+
+	c.Advance(time.Minute - time.Second)
+
+	require.Equal(t, 2, m.DeleteExpired())
+	require.Equal(t, []pair{{3, 30}, {1, 10}}, expired)
+	require.Equal(t, []pair{{2, 20}, {4, 40}}, pairs(m))
+}
+
+func TestMap_Iter(t *testing.T) {
+	c := newFakeClock()
+	m := New[int, int](time.Minute, WithClock(c))
+
+	m.StoreWithTTL(1, 10, time.Second)
+	m.Store(2, 20)
+
+	it := m.Iter()
+	require.True(t, it.Next())
+	require.Equal(t, c.Now().Add(time.Second), it.Expires())
+
+	c.Advance(time.Second)
+
+	require.Equal(t, []pair{{2, 20}}, pairs(m))
+	require.Equal(t, 2, m.Len())
+}
+
+func TestMap_Janitor(t *testing.T) {
+	c := newFakeClock()
+	m := New[int, int](time.Second, WithClock(c), WithJanitor(time.Minute))
+	defer m.Close()
+
+	expired := make(chan int, 1)
+	m.OnExpire(func(k, v int) {
+		expired <- k
+	})
+
+	m.Store(1, 10)
+	c.Advance(time.Second)
+
+	select {
+	case k := <-expired:
+		require.Fail(t, "entry is expired before the janitor tick", k)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	c.Advance(time.Minute)
+
+	select {
+	case k := <-expired:
+		require.Equal(t, 1, k)
+	case <-time.After(time.Second):
+		require.Fail(t, "entry is not expired by janitor")
+	}
+
+	m.Close()
+	m.Close()
+}
+
+func TestMap_Zero(t *testing.T) {
+	var m Map[int, int]
+	m.Store(1, 10)
+
+	v, ok := m.Load(1)
+	require.True(t, ok)
+	require.Equal(t, 10, v)
+	require.Zero(t, m.DeleteExpired())
+
+	m.Close()
+}
+
+// pairs returns the unexpired pairs of the map in order.
+func pairs(m *Map[int, int]) []pair {
+	var ps []pair
+	for it := m.Iter(); it.Next(); {
+		ps = append(ps, pair{it.Key(), it.Val()})
+	}
+
+	return ps
+}
@@ -0,0 +1,161 @@
+package ulist
+
+import (
+	"testing"
+
+	"github.com/glebziz/containers/list"
+)
+
+type point struct {
+	x, y, z int64
+}
+
+func newPoint(i int) point {
+	return point{x: int64(i), y: int64(i), z: int64(i)}
+}
+
+func newInt(i int) int {
+	return i
+}
+
+func BenchmarkList_PushBack(b *testing.B) {
+	b.Run("list int", func(b *testing.B) {
+		benchListPushBack(b, newInt)
+	})
+
+	b.Run("unrolled list int", func(b *testing.B) {
+		benchPushBack(b, newInt)
+	})
+
+	b.Run("list struct", func(b *testing.B) {
+		benchListPushBack(b, newPoint)
+	})
+
+	b.Run("unrolled list struct", func(b *testing.B) {
+		benchPushBack(b, newPoint)
+	})
+}
+
+func BenchmarkList_Iter(b *testing.B) {
+	b.Run("list int", func(b *testing.B) {
+		benchListIter(b, newInt)
+	})
+
+	b.Run("unrolled list int", func(b *testing.B) {
+		benchIter(b, newInt)
+	})
+
+	b.Run("list struct", func(b *testing.B) {
+		benchListIter(b, newPoint)
+	})
+
+	b.Run("unrolled list struct", func(b *testing.B) {
+		benchIter(b, newPoint)
+	})
+}
+
+func BenchmarkList_Get(b *testing.B) {
+	b.Run("list int", func(b *testing.B) {
+		benchListGet(b, newInt)
+	})
+
+	b.Run("unrolled list int", func(b *testing.B) {
+		benchGet(b, newInt)
+	})
+
+	b.Run("list struct", func(b *testing.B) {
+		benchListGet(b, newPoint)
+	})
+
+	b.Run("unrolled list struct", func(b *testing.B) {
+		benchGet(b, newPoint)
+	})
+}
+
+func benchListPushBack[T any](b *testing.B, v func(i int) T) {
+	b.ReportAllocs()
+
+	l := list.New[T]()
+
+	for i := 0; i < b.N; i++ {
+		l.PushBack(v(i))
+	}
+}
+
+func benchPushBack[T any](b *testing.B, v func(i int) T) {
+	b.ReportAllocs()
+
+	l := New[T]()
+
+	for i := 0; i < b.N; i++ {
+		l.PushBack(v(i))
+	}
+}
+
+func benchListIter[T any](b *testing.B, v func(i int) T) {
+	b.ReportAllocs()
+
+	l := list.New[T]()
+	for i := 0; i < b.N; i++ {
+		l.PushBack(v(i))
+	}
+
+	b.ResetTimer()
+
+	for it := l.Iter(); it.Next(); {
+		_ = it.Val()
+	}
+}
+
+func benchIter[T any](b *testing.B, v func(i int) T) {
+	b.ReportAllocs()
+
+	l := New[T]()
+	for i := 0; i < b.N; i++ {
+		l.PushBack(v(i))
+	}
+
+	b.ResetTimer()
+
+	for it := l.Iter(); it.Next(); {
+		_ = it.Val()
+	}
+}
+
+func benchListGet[T any](b *testing.B, v func(i int) T) {
+	const (
+		N = 10000
+	)
+
+	b.ReportAllocs()
+
+	l := list.New[T]()
+	for i := 0; i < N; i++ {
+		l.PushBack(v(i))
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		l.Get(i * 7919 % N)
+	}
+}
+
+func benchGet[T any](b *testing.B, v func(i int) T) {
+	const (
+		N = 10000
+	)
+
+	b.ReportAllocs()
+
+	l := New[T]()
+	for i := 0; i < N; i++ {
+		l.PushBack(v(i))
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		l.Get(i * 7919 % N)
+	}
+}
@@ -0,0 +1,21 @@
+package ulist_test
+
+import (
+	"fmt"
+
+	"github.com/glebziz/containers/ulist"
+)
+
+func ExampleNew() {
+	l := ulist.New[string]()
+
+	l.PushBack("World")
+	l.PushFront("Hello")
+	l.PushAfter(1, "!")
+
+	for it := l.Iter(); it.Next(); {
+		fmt.Print(it.Val(), " ")
+	}
+
+	// Output: Hello World !
+}
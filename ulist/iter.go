@@ -0,0 +1,52 @@
+package ulist
+
+import (
+	"github.com/glebziz/containers/internal/iter"
+	"github.com/glebziz/containers/internal/node"
+)
+
+// Iter is an iterator over the values of the unrolled list.
+type Iter[T any] struct {
+	dir  iter.Direction
+	c    *node.Node[chunk[T]]
+	stop *node.Node[chunk[T]]
+	i    int
+}
+
+// Next selects the next value and returns true if it exists.
+// Otherwise, it returns false.
+func (i *Iter[T]) Next() bool {
+	switch i.dir {
+	case iter.ForwardDir:
+		if i.c != i.stop && i.i+1 < i.c.Ref().n {
+			i.i++
+			return true
+		}
+
+		next := i.c.Next()
+		if next == nil || next == i.stop {
+			return false
+		}
+
+		i.c, i.i = next, 0
+	case iter.ReverseDir:
+		if i.c != i.stop && i.i > 0 {
+			i.i--
+			return true
+		}
+
+		prev := i.c.Prev()
+		if prev == nil || prev == i.stop {
+			return false
+		}
+
+		i.c, i.i = prev, prev.Ref().n-1
+	}
+
+	return true
+}
+
+// Val returns the value of the current node.
+func (i *Iter[T]) Val() T {
+	return i.c.Ref().vals[i.i]
+}
@@ -0,0 +1,149 @@
+package ulist
+
+import (
+	"github.com/glebziz/containers/internal/node"
+)
+
+// IndexFunc returns the index of the first element satisfying pred or -1 if none do.
+// The complexity is O(n).
+func (l *List[T]) IndexFunc(pred func(v T) bool) int {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	i := 0
+	for n := l.root.Next(); n != nil && n != &l.root; n = n.Next() {
+		c := n.Ref()
+		for j := 0; j < c.n; j++ {
+			if pred(c.vals[j]) {
+				return i + j
+			}
+		}
+
+		i += c.n
+	}
+
+	return -1
+}
+
+// LastIndexFunc returns the index of the last element satisfying pred or -1 if none do.
+// The complexity is O(n).
+func (l *List[T]) LastIndexFunc(pred func(v T) bool) int {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	i := l.len
+	for n := l.root.Prev(); n != nil && n != &l.root; n = n.Prev() {
+		c := n.Ref()
+		i -= c.n
+
+		for j := c.n - 1; j >= 0; j-- {
+			if pred(c.vals[j]) {
+				return i + j
+			}
+		}
+	}
+
+	return -1
+}
+
+// ContainsFunc reports whether at least one element of the list satisfies pred.
+// The complexity is O(n).
+func (l *List[T]) ContainsFunc(pred func(v T) bool) bool {
+	return l.IndexFunc(pred) >= 0
+}
+
+// RemoveFunc removes all elements satisfying pred and returns the number of removed elements.
+// The neighbouring chunks left small are merged, so the list stays packed.
+// The complexity is O(n).
+func (l *List[T]) RemoveFunc(pred func(v T) bool) int {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	removed := 0
+	var prev *node.Node[chunk[T]]
+	for n := l.root.Next(); n != nil && n != &l.root; {
+		next := n.Next()
+
+		c := n.Ref()
+		kept := 0
+		for j := 0; j < c.n; j++ {
+			if pred(c.vals[j]) {
+				reset(&c.vals[j])
+				continue
+			}
+
+			c.vals[kept] = c.vals[j]
+			kept++
+		}
+
+		clear(c.vals[kept:c.n])
+		removed += c.n - kept
+		l.len -= c.n - kept
+		c.n = kept
+
+		switch {
+		case c.n == 0:
+			l.release(n)
+		case prev != nil && prev.Ref().n+c.n <= chunkSize:
+			l.merge(prev)
+		default:
+			prev = n
+		}
+
+		n = next
+	}
+
+	return removed
+}
+
+// RemoveFirstFunc removes the first element satisfying pred and reports whether it was removed.
+// The complexity is O(n).
+func (l *List[T]) RemoveFirstFunc(pred func(v T) bool) bool {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	n, j := l.find(pred)
+	if n == nil {
+		return false
+	}
+
+	l.remove(n, j)
+	return true
+}
+
+// find returns the chunk of the first element satisfying pred and the position of the element in the chunk
+// or nil if none do.
+func (l *List[T]) find(pred func(v T) bool) (*node.Node[chunk[T]], int) {
+	for n := l.root.Next(); n != nil && n != &l.root; n = n.Next() {
+		c := n.Ref()
+		for j := 0; j < c.n; j++ {
+			if pred(c.vals[j]) {
+				return n, j
+			}
+		}
+	}
+
+	return nil, 0
+}
+
+// Index returns the index of the first occurrence of v in the list or -1 if not present.
+// The complexity is O(n).
+func Index[T comparable](l *List[T], v T) int {
+	return l.IndexFunc(func(e T) bool {
+		return e == v
+	})
+}
+
+// Contains reports whether v is present in the list.
+// The complexity is O(n).
+func Contains[T comparable](l *List[T], v T) bool {
+	return Index(l, v) >= 0
+}
+
+// RemoveValue removes all occurrences of v from the list and returns the number of removed elements.
+// The complexity is O(n).
+func RemoveValue[T comparable](l *List[T], v T) int {
+	return l.RemoveFunc(func(e T) bool {
+		return e == v
+	})
+}
@@ -0,0 +1,468 @@
+// Package ulist implements an unrolled doubly linked list with a pool of chunks.
+//
+// Every node of the list holds a small array of values,
+// so iterating over the list chases one pointer per chunk instead of one pointer per value.
+//
+// The list has the element API of list.List: the pushes and pops, Get, Set, Reverse, Rotate, Swap, Move
+// and the search functions. The bounded and sized lists, the hooks and the transactions of list.List
+// are not provided, so the values are moved between the chunks without an undo log and events,
+// and Set returns no error.
+//
+// To iterate over a list (where l is a *List):
+//
+//	it := l.Iter()
+//	for it.Next() {
+//		// do something with it.Val()
+//	}
+package ulist
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/glebziz/containers/internal/iter"
+	"github.com/glebziz/containers/internal/node"
+)
+
+const (
+	// chunkSize is the maximum number of values of a chunk.
+	chunkSize = 64
+	// mergeSize is the number of values below which a chunk is merged with the next one.
+	mergeSize = chunkSize / 4
+)
+
+// List represents an unrolled doubly linked list.
+// The zero value for List is an empty list ready to use.
+type List[T any] struct {
+	root node.Node[chunk[T]]
+	pool *node.Pool[chunk[T]]
+	m    sync.RWMutex
+	len  int
+}
+
+// chunk is a node value with the values packed at the beginning of the array.
+type chunk[T any] struct {
+	vals [chunkSize]T
+	n    int
+}
+
+// New returns an initialized list.
+func New[T any]() *List[T] {
+	l := &List[T]{
+		pool: node.NewPool[chunk[T]](),
+	}
+
+	return l
+}
+
+// NewPresized returns an initialized list with an allocated pool of chunks for size values.
+func NewPresized[T any](size int) *List[T] {
+	l := &List[T]{
+		pool: node.NewPoolPresized[chunk[T]](size/chunkSize + 1),
+	}
+
+	return l
+}
+
+// Len returns the number of elements of list.
+func (l *List[T]) Len() int {
+	return l.len
+}
+
+// Iter returns a list iterator with forward direction.
+func (l *List[T]) Iter() *Iter[T] {
+	return &Iter[T]{
+		dir:  iter.ForwardDir,
+		c:    &l.root,
+		stop: &l.root,
+	}
+}
+
+// RIter returns a list iterator with reverse direction.
+func (l *List[T]) RIter() *Iter[T] {
+	return &Iter[T]{
+		dir:  iter.ReverseDir,
+		c:    &l.root,
+		stop: &l.root,
+	}
+}
+
+// Front returns the value of the first element of the list or zero value if the list is empty.
+func (l *List[T]) Front() (v T) {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	if l.len == 0 {
+		return v
+	}
+
+	return l.root.Next().Ref().vals[0]
+}
+
+// Back returns the value of the last element of the list or zero value if the list is empty.
+func (l *List[T]) Back() (v T) {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	if l.len == 0 {
+		return v
+	}
+
+	c := l.root.Prev().Ref()
+	return c.vals[c.n-1]
+}
+
+// Get returns the value of the i-th element of the list or zero value if the list is empty or len < i.
+// The complexity is O(n/k), where k is the number of values of a chunk.
+func (l *List[T]) Get(i int) (v T) {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	n, j := l.get(i)
+	if n == nil {
+		return v
+	}
+
+	return n.Ref().vals[j]
+}
+
+// PushFront inserts a new value at the front of the list.
+// The complexity is O(k), where k is the number of values of a chunk.
+func (l *List[T]) PushFront(v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.lazyInit()
+
+	n := l.root.Next()
+	if n == &l.root || n.Ref().n == chunkSize {
+		n = l.newChunk(&l.root)
+	}
+
+	l.insert(n, 0, v)
+}
+
+// PushBack inserts a new value at the back of the list.
+// The complexity is O(1).
+func (l *List[T]) PushBack(v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.pushBack(v)
+}
+
+// PushAfter inserts a new value after the i-th element of the list.
+// The complexity is O(n/k + k), where k is the number of values of a chunk.
+func (l *List[T]) PushAfter(i int, v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	n, j := l.get(i)
+	if n == nil {
+		return
+	}
+
+	l.insert(n, j+1, v)
+}
+
+// PushBefore inserts a new value before the i-th element of the list.
+// The complexity is O(n/k + k), where k is the number of values of a chunk.
+func (l *List[T]) PushBefore(i int, v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	n, j := l.get(i)
+	if n == nil {
+		return
+	}
+
+	l.insert(n, j, v)
+}
+
+// PopFront returns and removes the first element of the list if the list is not empty.
+// The complexity is O(k), where k is the number of values of a chunk.
+func (l *List[T]) PopFront() T {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	return l.remove(l.root.Next(), 0)
+}
+
+// PopBack returns and removes the last element of the list if the list is not empty.
+// The complexity is O(1).
+func (l *List[T]) PopBack() (v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.len == 0 {
+		return v
+	}
+
+	n := l.root.Prev()
+	return l.remove(n, n.Ref().n-1)
+}
+
+// Remove removes the i-th element of the list if the i is less than len.
+// The complexity is O(n/k + k), where k is the number of values of a chunk.
+func (l *List[T]) Remove(i int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	n, j := l.get(i)
+	l.remove(n, j)
+}
+
+// Set replaces the value of the i-th element of the list if the i is less than len.
+// The complexity is O(n/k), where k is the number of values of a chunk.
+func (l *List[T]) Set(i int, v T) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if n, j := l.get(i); n != nil {
+		n.Ref().vals[j] = v
+	}
+}
+
+// Reverse reverses the order of the elements of the list in place.
+// The complexity is O(n).
+func (l *List[T]) Reverse() {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.len < 2 {
+		return
+	}
+
+	n := &l.root
+	for {
+		next := n.Next()
+		n.SetNext(n.Prev())
+		n.SetPrev(next)
+
+		n = next
+		if n == &l.root {
+			break
+		}
+
+		c := n.Ref()
+		slices.Reverse(c.vals[:c.n])
+	}
+}
+
+// Rotate rotates the list by n positions.
+// A positive n moves the last n elements to the front of the list,
+// a negative n moves the first -n elements to the back of the list.
+// The complexity is O(n/k + k), where k is the number of values of a chunk.
+func (l *List[T]) Rotate(n int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.len < 2 {
+		return
+	}
+
+	n %= l.len
+	if n == 0 {
+		return
+	}
+
+	if n < 0 {
+		n += l.len
+	}
+
+	front, j := l.get(l.len - n)
+	if j > 0 {
+		front = l.split(front, j)
+	}
+
+	l.root.Remove()
+	front.Prev().Insert(&l.root)
+}
+
+// Swap swaps the i-th and the j-th elements of the list
+// if both indexes are less than len.
+// The complexity is O(n/k), where k is the number of values of a chunk.
+func (l *List[T]) Swap(i, j int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	a, ai := l.get(i)
+	b, bi := l.get(j)
+	if a == nil || b == nil {
+		return
+	}
+
+	av, bv := &a.Ref().vals[ai], &b.Ref().vals[bi]
+	*av, *bv = *bv, *av
+}
+
+// Move moves the element at index from so that it ends up at index to
+// if both indexes are less than len.
+// The complexity is O(n/k + k), where k is the number of values of a chunk.
+func (l *List[T]) Move(from, to int) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if from == to || to < 0 || to >= l.len {
+		return
+	}
+
+	n, j := l.get(from)
+	if n == nil {
+		return
+	}
+
+	v := l.remove(n, j)
+	if to == l.len {
+		l.pushBack(v)
+		return
+	}
+
+	n, j = l.get(to)
+	l.insert(n, j, v)
+}
+
+// lazyInit lazily initializes a zero List value.
+func (l *List[T]) lazyInit() {
+	if l.pool == nil {
+		l.pool = node.NewPool[chunk[T]]()
+	}
+
+	if l.root.Next() == nil {
+		l.root.SetNext(&l.root)
+		l.root.SetPrev(&l.root)
+	}
+}
+
+// pushBack inserts the value at the back of the list.
+func (l *List[T]) pushBack(v T) {
+	l.lazyInit()
+
+	n := l.root.Prev()
+	if n == &l.root || n.Ref().n == chunkSize {
+		n = l.newChunk(l.root.Prev())
+	}
+
+	l.insert(n, n.Ref().n, v)
+}
+
+// newChunk inserts a new empty chunk after at.
+func (l *List[T]) newChunk(at *node.Node[chunk[T]]) *node.Node[chunk[T]] {
+	n := l.pool.Pop()
+	at.Insert(n)
+
+	return n
+}
+
+// insert inserts the value at the position j of the chunk n, increments len.
+// A full chunk is split in half first.
+func (l *List[T]) insert(n *node.Node[chunk[T]], j int, v T) {
+	c := n.Ref()
+	if c.n == chunkSize {
+		const half = chunkSize / 2
+
+		next := l.split(n, half)
+		if j > half {
+			c, j = next.Ref(), j-half
+		}
+	}
+
+	copy(c.vals[j+1:c.n+1], c.vals[j:c.n])
+	c.vals[j] = v
+	c.n++
+	l.len++
+}
+
+// split moves the values of the chunk n starting at the position j to a new chunk after n and returns it.
+func (l *List[T]) split(n *node.Node[chunk[T]], j int) *node.Node[chunk[T]] {
+	c := n.Ref()
+	next := l.newChunk(n)
+	nc := next.Ref()
+
+	nc.n = copy(nc.vals[:], c.vals[j:c.n])
+	clear(c.vals[j:c.n])
+	c.n = j
+
+	return next
+}
+
+// remove removes the value at the position j of the chunk n, decrements len and returns the value.
+// An empty chunk is returned to the pool and a small chunk is merged with the next one.
+func (l *List[T]) remove(n *node.Node[chunk[T]], j int) (v T) {
+	if n == nil || n == &l.root || l.len <= 0 {
+		return v
+	}
+
+	c := n.Ref()
+	v = c.vals[j]
+	reset(&c.vals[j])
+
+	copy(c.vals[j:c.n-1], c.vals[j+1:c.n])
+	c.n--
+	clear(c.vals[c.n : c.n+1])
+	l.len--
+
+	if c.n == 0 {
+		l.release(n)
+		return v
+	}
+
+	if next := n.Next(); c.n < mergeSize && next != &l.root && c.n+next.Ref().n <= chunkSize {
+		l.merge(n)
+	}
+
+	return v
+}
+
+// merge moves the values of the chunk next to n to the end of n and releases the next chunk.
+// The values of both chunks must fit into one chunk.
+func (l *List[T]) merge(n *node.Node[chunk[T]]) {
+	c, next := n.Ref(), n.Next()
+	nc := next.Ref()
+	copy(c.vals[c.n:], nc.vals[:nc.n])
+	c.n += nc.n
+	nc.n = 0
+	l.release(next)
+}
+
+// release removes the empty chunk n from the list and returns it to the pool.
+// The pool zeroes the values left in the chunk if they contain pointers.
+func (l *List[T]) release(n *node.Node[chunk[T]]) {
+	n.Remove()
+	l.pool.Push(n)
+}
+
+// reset resets the removed value if its pointer implements node.Resetter.
+// The slot of the value is zeroed by the caller when the values after it are shifted.
+func reset[T any](v *T) {
+	if r, ok := any(v).(node.Resetter); ok {
+		r.Reset()
+	}
+}
+
+// get returns the chunk of the i-th element and the position of the element in the chunk
+// or nil if the index is less than zero or greater than the len of the list.
+func (l *List[T]) get(i int) (*node.Node[chunk[T]], int) {
+	if l.len <= i || i < 0 {
+		return nil, 0
+	}
+
+	if i < l.len/2 {
+		n := l.root.Next()
+		for i >= n.Ref().n {
+			i -= n.Ref().n
+			n = n.Next()
+		}
+
+		return n, i
+	}
+
+	i = l.len - 1 - i
+	n := l.root.Prev()
+	for i >= n.Ref().n {
+		i -= n.Ref().n
+		n = n.Prev()
+	}
+
+	return n, n.Ref().n - 1 - i
+}
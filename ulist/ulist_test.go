@@ -0,0 +1,541 @@
+package ulist
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// build returns a list of consecutive values from zero packed into chunks of the sizes.
+func build(sizes ...int) *List[int] {
+	l := New[int]()
+	l.lazyInit()
+
+	v := 0
+	for _, size := range sizes {
+		c := l.newChunk(l.root.Prev()).Ref()
+		for ; c.n < size; c.n++ {
+			c.vals[c.n] = v
+			v++
+		}
+
+		l.len += size
+	}
+
+	return l
+}
+
+// chunks returns the numbers of values of the chunks of the list
+// and checks that no chunk is empty and the numbers add up to len.
+func chunks(t *testing.T, l *List[int]) []int {
+	t.Helper()
+
+	sizes := []int{}
+	total := 0
+	for n := l.root.Next(); n != nil && n != &l.root; n = n.Next() {
+		c := n.Ref()
+		require.Positive(t, c.n)
+		require.LessOrEqual(t, c.n, chunkSize)
+
+		sizes = append(sizes, c.n)
+		total += c.n
+	}
+
+	require.Equal(t, l.len, total)
+
+	return sizes
+}
+
+// vals returns the values of the list in order.
+func vals(l *List[int]) []int {
+	res := make([]int, 0, l.Len())
+	for it := l.Iter(); it.Next(); {
+		res = append(res, it.Val())
+	}
+
+	return res
+}
+
+// seq returns the values from 0 to n-1.
+func seq(n int) []int {
+	res := make([]int, n)
+	for i := range res {
+		res[i] = i
+	}
+
+	return res
+}
+
+// ins returns the values with v inserted at the index i.
+func ins(vals []int, i, v int) []int {
+	return append(vals[:i], append([]int{v}, vals[i:]...)...)
+}
+
+func TestList_Split(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		sizes     []int
+		change    func(l *List[int])
+		expChunks []int
+		expVals   []int
+	}{
+		{
+			name:  "push back to full chunk",
+			sizes: []int{chunkSize},
+			change: func(l *List[int]) {
+				l.PushBack(-1)
+			},
+			expChunks: []int{chunkSize, 1},
+			expVals:   append(seq(chunkSize), -1),
+		},
+		{
+			name:  "push front to full chunk",
+			sizes: []int{chunkSize},
+			change: func(l *List[int]) {
+				l.PushFront(-1)
+			},
+			expChunks: []int{1, chunkSize},
+			expVals:   append([]int{-1}, seq(chunkSize)...),
+		},
+		{
+			name:  "push before half",
+			sizes: []int{chunkSize},
+			change: func(l *List[int]) {
+				l.PushBefore(chunkSize/2, -1)
+			},
+			expChunks: []int{chunkSize/2 + 1, chunkSize / 2},
+			expVals:   ins(seq(chunkSize), chunkSize/2, -1),
+		},
+		{
+			name:  "push after half",
+			sizes: []int{chunkSize},
+			change: func(l *List[int]) {
+				l.PushAfter(chunkSize/2, -1)
+			},
+			expChunks: []int{chunkSize / 2, chunkSize/2 + 1},
+			expVals:   ins(seq(chunkSize), chunkSize/2+1, -1),
+		},
+		{
+			name:  "push after last",
+			sizes: []int{chunkSize},
+			change: func(l *List[int]) {
+				l.PushAfter(chunkSize-1, -1)
+			},
+			expChunks: []int{chunkSize / 2, chunkSize/2 + 1},
+			expVals:   append(seq(chunkSize), -1),
+		},
+		{
+			name:  "rotate inside chunk",
+			sizes: []int{chunkSize},
+			change: func(l *List[int]) {
+				l.Rotate(1)
+			},
+			expChunks: []int{1, chunkSize - 1},
+			expVals:   append([]int{chunkSize - 1}, seq(chunkSize-1)...),
+		},
+		{
+			name:  "rotate at chunk boundary",
+			sizes: []int{chunkSize, chunkSize},
+			change: func(l *List[int]) {
+				l.Rotate(chunkSize)
+			},
+			expChunks: []int{chunkSize, chunkSize},
+			expVals:   append(seq(2 * chunkSize)[chunkSize:], seq(chunkSize)...),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := build(tc.sizes...)
+			tc.change(l)
+
+			require.Equal(t, tc.expChunks, chunks(t, l))
+			require.Equal(t, tc.expVals, vals(l))
+		})
+	}
+}
+
+func TestList_Merge(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		sizes     []int
+		remove    int
+		expChunks []int
+	}{
+		{
+			name:      "below merge size",
+			sizes:     []int{mergeSize, chunkSize - mergeSize},
+			remove:    0,
+			expChunks: []int{chunkSize - 1},
+		},
+		{
+			name:      "at merge size",
+			sizes:     []int{mergeSize + 1, chunkSize - mergeSize},
+			remove:    0,
+			expChunks: []int{mergeSize, chunkSize - mergeSize},
+		},
+		{
+			name:      "exactly full",
+			sizes:     []int{mergeSize, chunkSize - mergeSize + 1},
+			remove:    0,
+			expChunks: []int{chunkSize},
+		},
+		{
+			name:      "over full",
+			sizes:     []int{mergeSize, chunkSize - mergeSize + 2},
+			remove:    0,
+			expChunks: []int{mergeSize - 1, chunkSize - mergeSize + 2},
+		},
+		{
+			name:      "last chunk",
+			sizes:     []int{chunkSize, mergeSize},
+			remove:    chunkSize,
+			expChunks: []int{chunkSize, mergeSize - 1},
+		},
+		{
+			name:      "empty chunk",
+			sizes:     []int{1, chunkSize},
+			remove:    0,
+			expChunks: []int{chunkSize},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := build(tc.sizes...)
+			expVals := vals(l)
+			expVals = append(expVals[:tc.remove], expVals[tc.remove+1:]...)
+
+			l.Remove(tc.remove)
+
+			require.Equal(t, tc.expChunks, chunks(t, l))
+			require.Equal(t, expVals, vals(l))
+		})
+	}
+}
+
+func TestList_Ops(t *testing.T) {
+	const (
+		N = 3*chunkSize + 5
+	)
+
+	for _, tc := range []struct {
+		name   string
+		change func(l *List[int])
+		model  func(vals []int) []int
+	}{
+		{
+			name: "set",
+			change: func(l *List[int]) {
+				l.Set(chunkSize, -1)
+				l.Set(N, -1)
+			},
+			model: func(vals []int) []int {
+				vals[chunkSize] = -1
+				return vals
+			},
+		},
+		{
+			name: "reverse",
+			change: func(l *List[int]) {
+				l.Reverse()
+			},
+			model: func(vals []int) []int {
+				for i, j := 0, len(vals)-1; i < j; i, j = i+1, j-1 {
+					vals[i], vals[j] = vals[j], vals[i]
+				}
+
+				return vals
+			},
+		},
+		{
+			name: "rotate negative",
+			change: func(l *List[int]) {
+				l.Rotate(-chunkSize - 3)
+			},
+			model: func(vals []int) []int {
+				return append(vals[chunkSize+3:], vals[:chunkSize+3]...)
+			},
+		},
+		{
+			name: "swap",
+			change: func(l *List[int]) {
+				l.Swap(1, N-1)
+				l.Swap(0, N)
+			},
+			model: func(vals []int) []int {
+				vals[1], vals[N-1] = vals[N-1], vals[1]
+				return vals
+			},
+		},
+		{
+			name: "move to back",
+			change: func(l *List[int]) {
+				l.Move(0, N-1)
+			},
+			model: func(vals []int) []int {
+				return append(vals[1:], vals[0])
+			},
+		},
+		{
+			name: "move backward",
+			change: func(l *List[int]) {
+				l.Move(2*chunkSize, 1)
+				l.Move(1, N)
+			},
+			model: func(vals []int) []int {
+				v := vals[2*chunkSize]
+				vals = append(vals[:2*chunkSize], vals[2*chunkSize+1:]...)
+				return append(vals[:1], append([]int{v}, vals[1:]...)...)
+			},
+		},
+		{
+			name: "remove func",
+			change: func(l *List[int]) {
+				l.RemoveFunc(func(v int) bool {
+					return v < chunkSize || v%3 == 0
+				})
+			},
+			model: func(vals []int) []int {
+				res := []int{}
+				for _, v := range vals {
+					if v >= chunkSize && v%3 != 0 {
+						res = append(res, v)
+					}
+				}
+
+				return res
+			},
+		},
+		{
+			name: "remove first func",
+			change: func(l *List[int]) {
+				l.RemoveFirstFunc(func(v int) bool {
+					return v > chunkSize
+				})
+			},
+			model: func(vals []int) []int {
+				return append(vals[:chunkSize+1], vals[chunkSize+2:]...)
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := build(chunkSize, chunkSize, chunkSize, 5)
+			tc.change(l)
+
+			expVals := tc.model(seq(N))
+			require.Equal(t, expVals, vals(l))
+			chunks(t, l)
+
+			for i, v := range expVals {
+				require.Equal(t, v, l.Get(i))
+			}
+		})
+	}
+}
+
+func TestList_RemoveFuncMerge(t *testing.T) {
+	t.Parallel()
+
+	l := New[int]()
+	for i := 0; i < 100*chunkSize; i++ {
+		l.PushBack(i)
+	}
+
+	require.Equal(t, 100*chunkSize-100, l.RemoveFunc(func(v int) bool {
+		return v%chunkSize != 0
+	}))
+	require.Equal(t, []int{chunkSize, 100 - chunkSize}, chunks(t, l))
+
+	for i := 0; i < 100; i++ {
+		require.Equal(t, i*chunkSize, l.Get(i))
+	}
+
+	l = build(chunkSize, chunkSize, chunkSize)
+	l.RemoveFunc(func(v int) bool {
+		return v >= chunkSize/2 && v < 2*chunkSize+chunkSize/2
+	})
+	require.Equal(t, []int{chunkSize}, chunks(t, l))
+
+	expVals := seq(3 * chunkSize)
+	require.Equal(t, append(expVals[:chunkSize/2], expVals[2*chunkSize+chunkSize/2:]...), vals(l))
+}
+
+func TestList_Search(t *testing.T) {
+	t.Parallel()
+
+	l := build(chunkSize, chunkSize)
+	l.Set(chunkSize+1, 1)
+
+	require.Equal(t, 1, Index(l, 1))
+	require.Equal(t, chunkSize+1, l.LastIndexFunc(func(v int) bool { return v == 1 }))
+	require.Equal(t, -1, Index(l, -1))
+	require.True(t, Contains(l, 2*chunkSize-1))
+	require.False(t, l.ContainsFunc(func(v int) bool { return v < 0 }))
+	require.Equal(t, 2, RemoveValue(l, 1))
+	require.Equal(t, 2*chunkSize-2, l.Len())
+}
+
+func TestList_ZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var l List[int]
+	require.Equal(t, 0, l.Get(0))
+	require.Equal(t, 0, l.Front())
+	require.Equal(t, 0, l.Back())
+	require.Equal(t, 0, l.PopFront())
+	require.Equal(t, 0, l.PopBack())
+
+	l.PushAfter(0, 1)
+	l.PushBefore(0, 1)
+	l.Remove(0)
+	l.Rotate(1)
+	l.Reverse()
+	require.Equal(t, 0, l.RemoveFunc(func(int) bool { return true }))
+	require.Equal(t, 0, l.Len())
+
+	l.PushBack(2)
+	l.PushFront(1)
+	require.Equal(t, []int{1, 2}, vals(&l))
+	require.Equal(t, 2, l.Back())
+}
+
+func TestList_Random(t *testing.T) {
+	const (
+		N = 10000
+	)
+
+	var (
+		r     = rand.New(rand.NewSource(1))
+		l     = New[int]()
+		model []int
+	)
+
+	for i := 0; i < N; i++ {
+		switch op := r.Intn(5); {
+		case op < 2 || len(model) == 0:
+			j := r.Intn(len(model) + 1)
+			if j == len(model) {
+				l.PushBack(i)
+			} else {
+				l.PushBefore(j, i)
+			}
+
+			model = append(model[:j], append([]int{i}, model[j:]...)...)
+		case op == 2:
+			j := r.Intn(len(model))
+			l.Remove(j)
+
+			model = append(model[:j], model[j+1:]...)
+		case op == 3:
+			from, to := r.Intn(len(model)), r.Intn(len(model))
+			l.Move(from, to)
+
+			v := model[from]
+			model = append(model[:from], model[from+1:]...)
+			model = append(model[:to], append([]int{v}, model[to:]...)...)
+		default:
+			j := r.Intn(len(model))
+			require.Equal(t, model[j], l.Get(j))
+		}
+	}
+
+	require.Equal(t, len(model), l.Len())
+	require.Equal(t, model, vals(l))
+	chunks(t, l)
+
+	for len(model) > 0 {
+		if r.Intn(2) == 0 {
+			require.Equal(t, model[0], l.PopFront())
+			model = model[1:]
+		} else {
+			require.Equal(t, model[len(model)-1], l.PopBack())
+			model = model[:len(model)-1]
+		}
+	}
+
+	require.Equal(t, 0, l.Len())
+	require.Equal(t, []int{}, vals(l))
+}
+
+func TestList_PushFrontMany(t *testing.T) {
+	const (
+		N = 1000
+	)
+
+	l := New[int]()
+	for i := N - 1; i >= 0; i-- {
+		l.PushFront(i)
+	}
+
+	for i := 0; i < N; i++ {
+		require.Equal(t, i, l.Get(i))
+	}
+
+	i := N
+	for it := l.RIter(); it.Next(); {
+		i--
+		require.Equal(t, i, it.Val())
+	}
+
+	require.Equal(t, 0, i)
+}
+
+type resetCounter struct {
+	resets *int
+}
+
+func (r *resetCounter) Reset() {
+	*r.resets++
+}
+
+func TestList_Reset(t *testing.T) {
+	t.Parallel()
+
+	const (
+		N = 3 * chunkSize
+	)
+
+	resets := 0
+
+	l := New[resetCounter]()
+	for i := 0; i < N; i++ {
+		l.PushBack(resetCounter{resets: &resets})
+	}
+
+	l.PopFront()
+	l.PopBack()
+	l.Remove(chunkSize)
+	require.Equal(t, 3, resets)
+
+	removed := 0
+	l.RemoveFunc(func(resetCounter) bool {
+		removed++
+		return removed%2 == 0
+	})
+	require.Equal(t, 3+(N-3)/2, resets)
+
+	for l.Len() > 0 {
+		l.PopFront()
+	}
+
+	require.Equal(t, N, resets)
+
+	free := l.pool.Stats().Free
+	require.Positive(t, free)
+
+	for i := 0; i < free; i++ {
+		c := l.pool.Pop().Ref()
+		require.Zero(t, c.n)
+
+		for _, v := range c.vals {
+			require.Nil(t, v.resets)
+		}
+	}
+}